@@ -19,6 +19,14 @@ var (
 
 	ErrHashPasswordsNotEqual = NewError("Invalid password", errors.New("hashes_not_equal"))
 	ErrDataNotValid          = NewError("Provided data is not valid", errors.New("not_valid"))
+	ErrTransitionNotAllowed  = NewError("Status transition is not allowed", errors.New("transition_not_allowed"))
+	ErrVersionMismatch       = NewError("Resource was modified, version does not match", errors.New("version_mismatch"))
+	ErrVersionRequired       = NewError("If-Match header is required", errors.New("version_required"))
+	ErrIdempotencyKeyReused  = NewError("Idempotency key was used with a different request", errors.New("idempotency_key_reused"))
+	ErrRequestInProgress     = NewError("Request with this idempotency key is in progress", errors.New("request_in_progress"))
+	ErrSessionExpired        = NewError("Session expired", errors.New("session_expired"))
+	ErrInvalidToken          = NewError("Access token is invalid or expired", errors.New("invalid_token"))
+	ErrRefreshTokenReused    = NewError("Refresh token was already used, log in again", errors.New("refresh_token_reused"))
 )
 
 func (a *AppError) Error() string {
@@ -44,6 +52,22 @@ func ParseHTTPErrStatusCode(err error) int {
 		return http.StatusUnauthorized
 	case errors.Is(err, ErrDataNotValid):
 		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrTransitionNotAllowed):
+		return http.StatusConflict
+	case errors.Is(err, ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrRequestInProgress):
+		return http.StatusConflict
+	case errors.Is(err, ErrSessionExpired):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrInvalidToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrRefreshTokenReused):
+		return http.StatusUnauthorized
 	}
 
 	return http.StatusInternalServerError
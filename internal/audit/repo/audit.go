@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/audit"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/pkg/postgres"
+	"strings"
+)
+
+const auditColumns = `id, task_id, action, id_user, actor, before, after, request_id, created_at`
+
+type auditRepository struct {
+	*postgres.Postgres
+}
+
+func NewAuditRepository(postgres *postgres.Postgres) audit.AuditRepository {
+	return &auditRepository{
+		postgres,
+	}
+}
+
+func (a *auditRepository) collectRow(row pgx.Row) (*entity.AuditEntry, error) {
+	var (
+		entry         entity.AuditEntry
+		before, after []byte
+	)
+	err := row.Scan(&entry.ID, &entry.TaskID, &entry.Action, &entry.UserID, &entry.ActorID, &before, &after,
+		&entry.RequestID, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Before, err = unmarshalTask(before); err != nil {
+		return nil, err
+	}
+	if entry.After, err = unmarshalTask(after); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (a *auditRepository) collectRows(rows pgx.Rows) ([]entity.AuditEntry, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.AuditEntry, error) {
+		entry, err := a.collectRow(row)
+		if err != nil {
+			return entity.AuditEntry{}, err
+		}
+		return *entry, nil
+	})
+}
+
+// marshalTask сохраняет состояние задачи в jsonb, nil записывается как null
+func marshalTask(task *entity.Task) ([]byte, error) {
+	if task == nil {
+		return nil, nil
+	}
+	return json.Marshal(task)
+}
+
+func unmarshalTask(data []byte) (*entity.Task, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	task := new(entity.Task)
+	if err := json.Unmarshal(data, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (a *auditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	before, err := marshalTask(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalTask(entry.After)
+	if err != nil {
+		return err
+	}
+
+	query := `insert into task_audit (task_id, action, id_user, actor, before, after, request_id, created_at)
+				values ($1, $2, $3, $4, $5, $6, $7, $8)
+				returning id`
+
+	return a.Conn(ctx).QueryRow(ctx, query, entry.TaskID, entry.Action, entry.UserID, entry.ActorID, before, after,
+		entry.RequestID, entry.CreatedAt).Scan(&entry.ID)
+}
+
+// Find возвращает страницу журнала от новых записей к старым и общее количество подходящих записей
+func (a *auditRepository) Find(ctx context.Context, query *entity.AuditQuery) ([]entity.AuditEntry, int, error) {
+	var (
+		builder strings.Builder
+		args    []interface{}
+	)
+	builder.WriteString(` from task_audit where true`)
+
+	if query.TaskID != nil {
+		args = append(args, *query.TaskID)
+		builder.WriteString(fmt.Sprintf(` and task_id = $%d`, len(args)))
+	}
+	if query.UserID != "" {
+		args = append(args, query.UserID)
+		builder.WriteString(fmt.Sprintf(` and id_user = $%d`, len(args)))
+	}
+	if query.ActorID != "" {
+		args = append(args, query.ActorID)
+		builder.WriteString(fmt.Sprintf(` and actor = $%d`, len(args)))
+	}
+	if query.Created.From != nil {
+		args = append(args, *query.Created.From)
+		builder.WriteString(fmt.Sprintf(` and created_at >= $%d`, len(args)))
+	}
+	if query.Created.To != nil {
+		args = append(args, *query.Created.To)
+		builder.WriteString(fmt.Sprintf(` and created_at <= $%d`, len(args)))
+	}
+
+	var total int
+	if err := a.Conn(ctx).QueryRow(ctx, `select count(*)`+builder.String(), args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, (query.Page-1)*query.PageSize, query.PageSize)
+	builder.WriteString(fmt.Sprintf(` order by id desc offset $%d limit $%d`, len(args)-1, len(args)))
+
+	rows, err := a.Conn(ctx).Query(ctx, `select `+auditColumns+builder.String(), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := a.collectRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+//go:generate mockgen -source storage.go -destination mock/audit_repository_mock.go -package mock
+type AuditRepository interface {
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+	Find(ctx context.Context, query *entity.AuditQuery) ([]entity.AuditEntry, int, error)
+}
@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+type AuditUsecase interface {
+	GetTaskHistory(ctx context.Context, taskID int, userID string, page, pageSize int) (*entity.AuditPage, error)
+	GetAuditLog(ctx context.Context, query *entity.AuditQuery) (*entity.AuditPage, error)
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/audit"
+	"go-todolist-sber/internal/entity"
+)
+
+const defaultAuditPageSize = 20
+
+type auditUsecase struct {
+	auditRepo audit.AuditRepository
+}
+
+func NewAuditUsecase(auditRepo audit.AuditRepository) audit.AuditUsecase {
+	return &auditUsecase{
+		auditRepo: auditRepo,
+	}
+}
+
+// GetTaskHistory возвращает изменения задачи пользователя от новых к старым.
+// История доступна и для задач в корзине, и для удаленных окончательно
+func (a *auditUsecase) GetTaskHistory(ctx context.Context, taskID int, userID string, page, pageSize int) (*entity.AuditPage, error) {
+	query := &entity.AuditQuery{TaskID: &taskID, UserID: userID, Page: page, PageSize: pageSize}
+
+	history, err := a.find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// чужая задача неотличима от несуществующей
+	if history.Total == 0 {
+		return nil, apperror.ErrNoRows
+	}
+	return history, nil
+}
+
+// GetAuditLog возвращает записи журнала всех пользователей по query
+func (a *auditUsecase) GetAuditLog(ctx context.Context, query *entity.AuditQuery) (*entity.AuditPage, error) {
+	if !query.Created.IsValid() {
+		return nil, apperror.ErrDataNotValid
+	}
+	return a.find(ctx, query)
+}
+
+func (a *auditUsecase) find(ctx context.Context, query *entity.AuditQuery) (*entity.AuditPage, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = defaultAuditPageSize
+	}
+
+	entries, total, err := a.auditRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewAuditPage(entries, total, query.Page, query.PageSize), nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/audit/mock"
+	"go-todolist-sber/internal/entity"
+	"testing"
+	"time"
+)
+
+func TestAuditUsecase_GetTaskHistory(t *testing.T) {
+	t.Parallel()
+
+	taskID := 5
+	entries := []entity.AuditEntry{
+		{ID: 2, TaskID: taskID, Action: entity.AuditStatus, UserID: "uuid", ActorID: "uuid"},
+		{ID: 1, TaskID: taskID, Action: entity.AuditCreate, UserID: "uuid", ActorID: "uuid"},
+	}
+
+	type mockBehavior func(r *mock.MockAuditRepository)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         *entity.AuditPage
+		wantErr      error
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(r *mock.MockAuditRepository) {
+				query := &entity.AuditQuery{TaskID: &taskID, UserID: "uuid", Page: 1, PageSize: defaultAuditPageSize}
+				r.EXPECT().Find(context.Background(), query).Return(entries, 2, nil)
+			},
+			want:    entity.NewAuditPage(entries, 2, 1, defaultAuditPageSize),
+			wantErr: nil,
+		},
+		{
+			name: "foreign or unknown task",
+			mockBehavior: func(r *mock.MockAuditRepository) {
+				r.EXPECT().Find(context.Background(), gomock.Any()).Return(nil, 0, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockAuditRepo := mock.NewMockAuditRepository(ctrl)
+			tt.mockBehavior(mockAuditRepo)
+
+			auditUsecase := NewAuditUsecase(mockAuditRepo)
+			history, err := auditUsecase.GetTaskHistory(context.Background(), taskID, "uuid", 0, 0)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, history)
+		})
+	}
+}
+
+func TestAuditUsecase_GetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(r *mock.MockAuditRepository)
+
+	tests := []struct {
+		name         string
+		query        *entity.AuditQuery
+		mockBehavior mockBehavior
+		want         *entity.AuditPage
+		wantErr      error
+	}{
+		{
+			name:  "empty log",
+			query: &entity.AuditQuery{ActorID: "uuid", Created: entity.TimeRange{To: &from}},
+			mockBehavior: func(r *mock.MockAuditRepository) {
+				query := &entity.AuditQuery{ActorID: "uuid", Created: entity.TimeRange{To: &from}, Page: 1, PageSize: defaultAuditPageSize}
+				r.EXPECT().Find(context.Background(), query).Return(nil, 0, nil)
+			},
+			want:    entity.NewAuditPage(nil, 0, 1, defaultAuditPageSize),
+			wantErr: nil,
+		},
+		{
+			name:         "inverted range",
+			query:        &entity.AuditQuery{Created: entity.TimeRange{From: &from, To: &to}},
+			mockBehavior: func(r *mock.MockAuditRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockAuditRepo := mock.NewMockAuditRepository(ctrl)
+			tt.mockBehavior(mockAuditRepo)
+
+			auditUsecase := NewAuditUsecase(mockAuditRepo)
+			log, err := auditUsecase.GetAuditLog(context.Background(), tt.query)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, log)
+		})
+	}
+}
@@ -4,12 +4,19 @@ import (
 	"github.com/joho/godotenv"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type (
 	Config struct {
 		Postgres    Postgres    `json:"postgres"`
 		HTTTPServer HTTTPServer `json:"http_server"`
+		Reminder    Reminder    `json:"reminder"`
+		Trash       Trash       `json:"trash"`
+		Idempotency Idempotency `json:"idempotency"`
+		Session     Session     `json:"session"`
+		Auth        Auth        `json:"auth"`
 
 		Salt      string `json:"salt"`
 		SecretKey string `json:"secret_key"`
@@ -23,7 +30,84 @@ type (
 		Hostname   string `json:"hostname"`
 		Port       string `json:"port"`
 		TypeServer string `json:"type_server"`
+		// TrustedProxies — адреса или сети прокси, от которых принимаются X-Forwarded-For и X-Real-IP
+		TrustedProxies []string `json:"trusted_proxies"`
 	}
+
+	Reminder struct {
+		Interval time.Duration `json:"interval"`
+		// Notifiers — способы доставки напоминаний: log, webhook, smtp
+		Notifiers  []string `json:"notifiers"`
+		WebhookURL string   `json:"webhook_url"`
+		SMTP       SMTP     `json:"smtp"`
+	}
+
+	// Trash задает, сколько задачи хранятся в корзине и как часто корзина очищается
+	Trash struct {
+		Retention     time.Duration `json:"retention"`
+		PurgeInterval time.Duration `json:"purge_interval"`
+	}
+
+	// Idempotency задает, сколько хранятся ответы на запросы с Idempotency-Key и как часто удаляются истекшие
+	Idempotency struct {
+		TTL           time.Duration `json:"ttl"`
+		PurgeInterval time.Duration `json:"purge_interval"`
+	}
+
+	// Session задает время жизни сессии без запросов, предельное время жизни от входа
+	// и частоту удаления истекших сессий
+	Session struct {
+		TTL           time.Duration `json:"ttl"`
+		MaxLifetime   time.Duration `json:"max_lifetime"`
+		PurgeInterval time.Duration `json:"purge_interval"`
+	}
+
+	// Auth выбирает способ входа: session — cookie с сессией в базе, jwt — подписанные access token
+	// и refresh-токены, access token проверяется без обращения к базе
+	Auth struct {
+		Mode string `json:"mode"`
+		JWT  JWT    `json:"jwt"`
+	}
+
+	// JWT задает время жизни токенов, предельное время жизни входа и ключи подписи. Keys — ключи вида kid:seed, первым подписываются
+	// новые токены, остальные только принимаются, пока не истекут выданные ими токены
+	JWT struct {
+		Issuer        string        `json:"issuer"`
+		AccessTTL     time.Duration `json:"access_ttl"`
+		RefreshTTL    time.Duration `json:"refresh_ttl"`
+		MaxLifetime   time.Duration `json:"max_lifetime"`
+		Keys          []string      `json:"-"`
+		PurgeInterval time.Duration `json:"purge_interval"`
+	}
+
+	SMTP struct {
+		Addr     string `json:"addr"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+		From     string `json:"from"`
+		To       string `json:"to"`
+	}
+)
+
+const (
+	AuthModeSession = "session"
+	AuthModeJWT     = "jwt"
+)
+
+const (
+	defaultReminderInterval         = 30 * time.Second
+	defaultTrashRetention           = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval       = time.Hour
+	defaultIdempotencyTTL           = 24 * time.Hour
+	defaultIdempotencyPurgeInterval = time.Hour
+	defaultSessionTTL               = time.Hour
+	defaultSessionMaxLifetime       = 7 * 24 * time.Hour
+	defaultSessionPurgeInterval     = time.Hour
+	defaultJWTIssuer                = "go-todolist-sber"
+	defaultJWTAccessTTL             = 15 * time.Minute
+	defaultJWTRefreshTTL            = 30 * 24 * time.Hour
+	defaultJWTMaxLifetime           = 90 * 24 * time.Hour
+	defaultJWTPurgeInterval         = time.Hour
 )
 
 func New() (*Config, error) {
@@ -37,9 +121,46 @@ func New() (*Config, error) {
 			URL: os.Getenv("POSTGRES_URL"),
 		},
 		HTTTPServer: HTTTPServer{
-			Hostname:   os.Getenv("HTTP_SERVER_HOSTNAME"),
-			Port:       os.Getenv("HTTP_SERVER_PORT"),
-			TypeServer: os.Getenv("HTTP_SERVER_TYPE_SERVER"),
+			Hostname:       os.Getenv("HTTP_SERVER_HOSTNAME"),
+			Port:           os.Getenv("HTTP_SERVER_PORT"),
+			TypeServer:     os.Getenv("HTTP_SERVER_TYPE_SERVER"),
+			TrustedProxies: parseEnvList(os.Getenv("HTTP_TRUSTED_PROXIES")),
+		},
+		Reminder: Reminder{
+			Interval:   parseEnvDuration(os.Getenv("REMINDER_INTERVAL"), defaultReminderInterval),
+			Notifiers:  parseEnvList(os.Getenv("REMINDER_NOTIFIERS"), "log"),
+			WebhookURL: os.Getenv("REMINDER_WEBHOOK_URL"),
+			SMTP: SMTP{
+				Addr:     os.Getenv("SMTP_ADDR"),
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     os.Getenv("SMTP_FROM"),
+				To:       os.Getenv("SMTP_TO"),
+			},
+		},
+		Trash: Trash{
+			Retention:     parseEnvDuration(os.Getenv("TRASH_RETENTION"), defaultTrashRetention),
+			PurgeInterval: parseEnvDuration(os.Getenv("TRASH_PURGE_INTERVAL"), defaultTrashPurgeInterval),
+		},
+		Idempotency: Idempotency{
+			TTL:           parseEnvDuration(os.Getenv("IDEMPOTENCY_TTL"), defaultIdempotencyTTL),
+			PurgeInterval: parseEnvDuration(os.Getenv("IDEMPOTENCY_PURGE_INTERVAL"), defaultIdempotencyPurgeInterval),
+		},
+		Session: Session{
+			TTL:           parseEnvDuration(os.Getenv("SESSION_TTL"), defaultSessionTTL),
+			MaxLifetime:   parseEnvDuration(os.Getenv("SESSION_MAX_LIFETIME"), defaultSessionMaxLifetime),
+			PurgeInterval: parseEnvDuration(os.Getenv("SESSION_PURGE_INTERVAL"), defaultSessionPurgeInterval),
+		},
+		Auth: Auth{
+			Mode: parseEnvString(os.Getenv("AUTH_MODE"), AuthModeSession),
+			JWT: JWT{
+				Issuer:        parseEnvString(os.Getenv("JWT_ISSUER"), defaultJWTIssuer),
+				AccessTTL:     parseEnvDuration(os.Getenv("JWT_ACCESS_TTL"), defaultJWTAccessTTL),
+				RefreshTTL:    parseEnvDuration(os.Getenv("JWT_REFRESH_TTL"), defaultJWTRefreshTTL),
+				MaxLifetime:   parseEnvDuration(os.Getenv("JWT_MAX_LIFETIME"), defaultJWTMaxLifetime),
+				Keys:          parseEnvList(os.Getenv("JWT_KEYS")),
+				PurgeInterval: parseEnvDuration(os.Getenv("JWT_PURGE_INTERVAL"), defaultJWTPurgeInterval),
+			},
 		},
 		Salt:      os.Getenv("SALT"),
 		SecretKey: os.Getenv("SECRET_KEY"),
@@ -55,3 +176,31 @@ func parseEnvInt(value string) int {
 	}
 	return intValue
 }
+
+func parseEnvDuration(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+	return duration
+}
+
+func parseEnvString(value string, fallback string) string {
+	if value = strings.TrimSpace(value); value == "" {
+		return fallback
+	}
+	return value
+}
+
+func parseEnvList(value string, fallback ...string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	if len(list) == 0 {
+		return fallback
+	}
+	return list
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/audit"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/pkg/logger"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type auditHandler struct {
+	auditUsecase audit.AuditUsecase
+	log          *logger.Logger
+}
+
+func NewAuditHandler(auditUsecase audit.AuditUsecase, log *logger.Logger) *auditHandler {
+	return &auditHandler{
+		auditUsecase: auditUsecase,
+		log:          log,
+	}
+}
+
+// GetTaskHistoryHandler godoc
+// @Summary Get task history
+// @Tags Task
+// @Description get changes of user task from newest to oldest: action, state before and after, actor and request id.
+// @Description History is kept for tasks in trash and for permanently deleted tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Param page query int false "page number starting from 1" Format(page)
+// @Param page_size query int false "entries per page, 20 by default, at most 100" Format(page_size)
+// @Success 200 {object} entity.AuditPage
+// @Failure 400 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/history [get]
+func (a *auditHandler) GetTaskHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	taskID, err := strconv.Atoi(param)
+	if err != nil {
+		a.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	page, pageSize, err := parsePage(r.URL.Query())
+	if err != nil {
+		a.log.Error("parsePage: %v", err)
+		QueryError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	history, err := a.auditUsecase.GetTaskHistory(r.Context(), taskID, userID, page, pageSize)
+	if err != nil {
+		a.log.Error("auditUsecase.GetTaskHistory: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(history)
+}
+
+// GetAuditLogHandler godoc
+// @Summary Get audit log
+// @Tags Audit
+// @Description get changes of all users tasks from newest to oldest, available only to the admin
+// @Accept json
+// @Produce json
+// @Param user_id query string false "task owner id"
+// @Param actor_id query string false "id of the user who made the change"
+// @Param task_id query int false "task id"
+// @Param created_from query string false "change time from, inclusive: 31.12.2023, 31.12.2023 18:00 or ISO 8601" Format(datetime)
+// @Param created_to query string false "change time to, inclusive, date without time includes the whole day" Format(datetime)
+// @Param page query int false "page number starting from 1" Format(page)
+// @Param page_size query int false "entries per page, 20 by default, at most 100" Format(page_size)
+// @Success 200 {object} entity.AuditPage
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /audit [get]
+func (a *auditHandler) GetAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	role := getRole(r.Context())
+	if role != "admin" {
+		AccessError(w)
+		return
+	}
+
+	query, err := parseAuditQuery(r.URL.Query())
+	if err != nil {
+		a.log.Error("parseAuditQuery: %v", err)
+		paramOptionError(w, err)
+		return
+	}
+
+	log, err := a.auditUsecase.GetAuditLog(r.Context(), query)
+	if err != nil {
+		a.log.Error("auditUsecase.GetAuditLog: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(log)
+}
+
+func parseAuditQuery(values url.Values) (*entity.AuditQuery, error) {
+	query := &entity.AuditQuery{
+		UserID:  values.Get("user_id"),
+		ActorID: values.Get("actor_id"),
+	}
+
+	var err error
+	query.Page, query.PageSize, err = parsePage(values)
+	if err != nil {
+		return nil, err
+	}
+
+	if taskIDString := values.Get("task_id"); taskIDString != "" {
+		taskID, err := strconv.Atoi(taskIDString)
+		if err != nil {
+			return nil, err
+		}
+		query.TaskID = &taskID
+	}
+
+	if err := parseTimeRange(values, "created", &query.Created); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+// parsePage разбирает номер и размер страницы, 0 означает значение по умолчанию
+func parsePage(values url.Values) (int, int, error) {
+	var page, pageSize int
+
+	if pageString := values.Get("page"); pageString != "" {
+		var err error
+		page, err = strconv.Atoi(pageString)
+		if err != nil {
+			return 0, 0, err
+		}
+		if page < 1 {
+			return 0, 0, fmt.Errorf("page out of range: %d", page)
+		}
+	}
+
+	if pageSizeString := values.Get("page_size"); pageSizeString != "" {
+		var err error
+		pageSize, err = strconv.Atoi(pageSizeString)
+		if err != nil {
+			return 0, 0, err
+		}
+		if pageSize < 1 || pageSize > entity.MaxPageSize {
+			return 0, 0, fmt.Errorf("page size out of range: %d", pageSize)
+		}
+	}
+
+	return page, pageSize, nil
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/idempotency"
+	"go-todolist-sber/pkg/logger"
+	"io"
+	"net/http"
+	"slices"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+)
+
+// responseRecorder передает ответ клиенту и запоминает его для повторов запроса
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// IdempotencyMiddleware выполняет запрос с заголовком Idempotency-Key один раз: повтор с тем же ключом
+// и тем же телом получает сохраненный ответ, повтор с другим телом отклоняется.
+// Ответ с ошибкой сервера не сохраняется, такой запрос можно повторить. Запросы без ключа проходят как обычно
+func IdempotencyMiddleware(idem idempotency.IdempotencyUsecase, log *logger.Logger) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(idempotencyKeyHeader)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(value) > maxIdempotencyKeyLen {
+				ErrorJSON(w, "idempotency key is too long", http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				log.Error("io.ReadAll: %v", err)
+				DecodingError(w)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			hash := sha256.New()
+			io.WriteString(hash, r.Method+" "+r.URL.Path+"\n")
+			hash.Write(body)
+
+			key := &entity.IdempotencyKey{
+				UserID:      getUserID(r.Context()),
+				Key:         value,
+				RequestHash: hex.EncodeToString(hash.Sum(nil)),
+			}
+
+			stored, err := idem.Begin(context.Background(), key)
+			if err != nil {
+				log.Error("idempotencyUsecase.Begin: %v", err)
+				HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+				return
+			}
+			if stored != nil {
+				replay(w, stored)
+				return
+			}
+
+			completed := false
+			// ключ освобождается и при панике обработчика, иначе повтор получал бы 409 до истечения ключа
+			defer func() {
+				if completed {
+					return
+				}
+				if err := idem.Abort(context.Background(), key); err != nil {
+					log.Error("idempotencyUsecase.Abort: %v", err)
+				}
+			}()
+
+			// заголовки внешних middleware, например CORS, выставляются и при повторе, сохраняются только заголовки обработчика
+			before := w.Header().Clone()
+			recorder := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status == 0 || recorder.status >= http.StatusInternalServerError {
+				return
+			}
+
+			key.StatusCode = recorder.status
+			key.Header = handlerHeader(before, w.Header())
+			key.Response = recorder.body.Bytes()
+			if err := idem.Complete(context.Background(), key); err != nil {
+				log.Error("idempotencyUsecase.Complete: %v", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// replay повторяет сохраненный ответ, заголовок Idempotent-Replayed отличает его от нового
+func replay(w http.ResponseWriter, stored *entity.IdempotencyKey) {
+	for name, values := range stored.Header {
+		w.Header().Del(name)
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Response)
+}
+
+// handlerHeader возвращает заголовки, которые обработчик добавил или изменил после before
+func handlerHeader(before, after http.Header) http.Header {
+	header := make(http.Header)
+	for name, values := range after {
+		if !slices.Equal(before[name], values) {
+			header[name] = slices.Clone(values)
+		}
+	}
+	return header
+}
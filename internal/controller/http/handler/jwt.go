@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/jwtauth"
+	"go-todolist-sber/internal/user"
+	"go-todolist-sber/pkg/logger"
+	"net/http"
+)
+
+type jwtHandler struct {
+	userUsecase user.UserUsecase
+	jwtUsecase  jwtauth.JWTUsecase
+	log         *logger.Logger
+}
+
+func NewJWTHandler(userUsecase user.UserUsecase, jwtUsecase jwtauth.JWTUsecase, log *logger.Logger) *jwtHandler {
+	return &jwtHandler{
+		userUsecase: userUsecase,
+		jwtUsecase:  jwtUsecase,
+		log:         log,
+	}
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type AuthResponse struct {
+	User *entity.User `json:"user"`
+	entity.TokenPair
+}
+
+// RegisterHandler godoc
+// @Summary Register new user with JWT
+// @Tags Auth
+// @Description register new user in jwt auth mode, returns user, access token and refresh token
+// @Accept json
+// @Produce json
+// @Param input body UserRequest true "user login and password"
+// @Success 201 {object} AuthResponse
+// @Failure 400 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /auth/register [post]
+func (j *jwtHandler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(UserRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		j.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	user, err := j.userUsecase.Register(context.Background(), uuid.New().String(), data.Login, data.Password)
+	if err != nil {
+		j.log.Error("userUsecase.Register: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	j.issueTokens(w, r, user, http.StatusCreated)
+}
+
+// LoginHandler godoc
+// @Summary Login user with JWT
+// @Tags Auth
+// @Description login user in jwt auth mode, returns user, short-lived access token and refresh token
+// @Accept json
+// @Produce json
+// @Param input body UserRequest true "user login and password"
+// @Success 200 {object} AuthResponse
+// @Failure 400 {object} JSONError
+// @Failure 401 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /auth/login [post]
+func (j *jwtHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(UserRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		j.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	user, err := j.userUsecase.Login(context.Background(), data.Login, data.Password)
+	if err != nil {
+		j.log.Error("userUsecase.Login: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	j.issueTokens(w, r, user, http.StatusOK)
+}
+
+// RefreshHandler godoc
+// @Summary Refresh JWT
+// @Tags Auth
+// @Description exchange refresh token for a new access token and refresh token, every refresh token can be used once. Reusing a refresh token revokes all tokens of the login
+// @Accept json
+// @Produce json
+// @Param input body RefreshRequest true "refresh token"
+// @Success 200 {object} entity.TokenPair
+// @Failure 400 {object} JSONError
+// @Failure 401 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /auth/refresh [post]
+func (j *jwtHandler) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(RefreshRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		j.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	pair, err := j.jwtUsecase.Refresh(context.Background(), data.RefreshToken, deviceFromRequest(r))
+	if err != nil {
+		j.log.Error("jwtUsecase.Refresh: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(pair)
+}
+
+// LogoutHandler godoc
+// @Summary Logout user with JWT
+// @Tags Auth
+// @Description revoke refresh token and all tokens issued after the same login, access tokens stay valid until they expire
+// @Accept json
+// @Produce json
+// @Param input body RefreshRequest true "refresh token"
+// @Success 200
+// @Failure 400 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /auth/logout [post]
+func (j *jwtHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(RefreshRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		j.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	if err := j.jwtUsecase.Logout(context.Background(), data.RefreshToken); err != nil {
+		j.log.Error("jwtUsecase.Logout: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// JWKSHandler godoc
+// @Summary Get JWT public keys
+// @Tags Auth
+// @Description public keys for access token verification in JWK Set format
+// @Produce json
+// @Success 200 {object} jwt.JWKS
+// @Router /.well-known/jwks.json [get]
+func (j *jwtHandler) JWKSHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(j.jwtUsecase.JWKS())
+}
+
+func (j *jwtHandler) issueTokens(w http.ResponseWriter, r *http.Request, user *entity.User, status int) {
+	pair, err := j.jwtUsecase.Login(context.Background(), user, deviceFromRequest(r))
+	if err != nil {
+		j.log.Error("jwtUsecase.Login: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(status)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(AuthResponse{User: user, TokenPair: *pair})
+}
@@ -2,10 +2,19 @@ package handler
 
 import (
 	"context"
+	"errors"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/gorilla/sessions"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/jwtauth"
 	"go-todolist-sber/internal/session"
+	"go-todolist-sber/internal/token"
 	"go-todolist-sber/pkg/logger"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
 	"time"
 )
 
@@ -21,9 +30,17 @@ func MiddlewareLogger(log *logger.Logger) middleware {
 	}
 }
 
-func AuthMiddleware(sess session.SessionUsecase, store *sessions.CookieStore) middleware {
+const bearerPrefix = "Bearer "
+
+// AuthMiddleware пускает запрос по персональному токену из заголовка Authorization, а без него — по cookie сессии
+func AuthMiddleware(sess session.SessionUsecase, tokens token.TokenUsecase, store *sessions.CookieStore) middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if secret, ok := bearerToken(r); ok {
+				serveAccessToken(w, r, next, tokens, secret)
+				return
+			}
+
 			session, err := store.Get(r, "session.id")
 			if err != nil {
 				ErrorJSON(w, "Internal server error", http.StatusUnauthorized)
@@ -34,6 +51,10 @@ func AuthMiddleware(sess session.SessionUsecase, store *sessions.CookieStore) mi
 				sessionId := session.Values["sessionID"]
 
 				data, err := sess.GetToken(context.Background(), sessionId.(string))
+				if errors.Is(err, apperror.ErrSessionExpired) {
+					HandleError(w, err, http.StatusUnauthorized)
+					return
+				}
 				if err != nil {
 					ErrorJSON(w, err.Error(), http.StatusForbidden)
 					return
@@ -41,6 +62,8 @@ func AuthMiddleware(sess session.SessionUsecase, store *sessions.CookieStore) mi
 
 				ctx := context.WithValue(r.Context(), "userID", data.UserID)
 				ctx = context.WithValue(ctx, "role", data.Role)
+				ctx = context.WithValue(ctx, "token", data.Token)
+				ctx = entity.WithActor(ctx, entity.Actor{UserID: data.UserID, RequestID: chiMiddleware.GetReqID(r.Context())})
 				next.ServeHTTP(w, r.WithContext(ctx))
 			} else {
 				ErrorJSON(w, "Forbidden", http.StatusForbidden)
@@ -49,3 +72,135 @@ func AuthMiddleware(sess session.SessionUsecase, store *sessions.CookieStore) mi
 		})
 	}
 }
+
+// JWTAuthMiddleware пускает запрос по access token из заголовка Authorization. Подпись и срок проверяются
+// без обращения к базе, персональные токены принимаются так же, как в AuthMiddleware
+func JWTAuthMiddleware(jwt jwtauth.JWTUsecase, tokens token.TokenUsecase) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret, ok := bearerToken(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				ErrorJSON(w, "Authorization header is required", http.StatusUnauthorized)
+				return
+			}
+			// у JWT три части через точку, у персонального токена точек нет
+			if strings.Count(secret, ".") != 2 {
+				serveAccessToken(w, r, next, tokens, secret)
+				return
+			}
+
+			claims, err := jwt.Verify(secret)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "userID", claims.Subject)
+			ctx = context.WithValue(ctx, "role", claims.Role)
+			ctx = entity.WithActor(ctx, entity.Actor{UserID: claims.Subject, RequestID: chiMiddleware.GetReqID(r.Context())})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(bearerPrefix):]), true
+}
+
+// serveAccessToken пускает запрос по персональному токену с учетом его области
+func serveAccessToken(w http.ResponseWriter, r *http.Request, next http.Handler, tokens token.TokenUsecase, secret string) {
+	tok, err := tokens.Authenticate(context.Background(), secret)
+	if err != nil {
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+	if !tok.Allows(r.Method) {
+		ErrorJSON(w, "token scope does not allow this request", http.StatusForbidden)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "userID", tok.UserID)
+	ctx = context.WithValue(ctx, "role", tok.Role)
+	ctx = context.WithValue(ctx, "accessTokenID", tok.ID)
+	ctx = entity.WithActor(ctx, entity.Actor{UserID: tok.UserID, RequestID: chiMiddleware.GetReqID(r.Context())})
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ForbidAccessTokenMiddleware закрывает маршрут для персональных токенов. Ставится после AuthMiddleware
+// на управление токенами и сессиями, чтобы утекший токен не мог выпустить себе замену или завершить сессии
+func ForbidAccessTokenMiddleware() middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id, _ := r.Context().Value("accessTokenID").(string); id != "" {
+				ErrorJSON(w, "personal access token can not manage tokens and sessions", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RealIPMiddleware подставляет адрес клиента из X-Forwarded-For или X-Real-IP, только если запрос пришел
+// от доверенного прокси. X-Forwarded-For разбирается справа налево до первого адреса не из trusted: левые
+// записи клиент может подставить сам. Без доверенных прокси заголовки игнорируются
+func RealIPMiddleware(trusted []netip.Prefix) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip, ok := forwardedIP(r, trusted); ok {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func forwardedIP(r *http.Request, trusted []netip.Prefix) (string, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !isTrusted(host, trusted) {
+		return "", false
+	}
+
+	if header := r.Header.Values("X-Forwarded-For"); len(header) > 0 {
+		hops := strings.Split(strings.Join(header, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil {
+				return "", false
+			}
+			if !isTrusted(hop, trusted) {
+				return hop, true
+			}
+		}
+		return "", false
+	}
+
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+		if _, err := netip.ParseAddr(ip); err == nil {
+			return ip, true
+		}
+	}
+	return "", false
+}
+
+func isTrusted(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/project"
+	"go-todolist-sber/internal/task"
+	"go-todolist-sber/pkg/logger"
+	"net/http"
+	"strconv"
+)
+
+type projectHandler struct {
+	projectUsecase project.ProjectUsecase
+	taskUsecase    task.TaskUsecase
+	log            *logger.Logger
+}
+
+func NewProjectHandler(projectUsecase project.ProjectUsecase, taskUsecase task.TaskUsecase, log *logger.Logger) *projectHandler {
+	return &projectHandler{
+		projectUsecase: projectUsecase,
+		taskUsecase:    taskUsecase,
+		log:            log,
+	}
+}
+
+type ProjectRequest struct {
+	Name string `json:"name"`
+}
+
+type ArchiveRequest struct {
+	Archived bool `json:"archived"`
+}
+
+// GetProjectsHandler godoc
+// @Summary Get user projects
+// @Tags Project
+// @Description get projects of user from context, optionally only archived or only active
+// @Accept json
+// @Produce json
+// @Param archived query boolean false "archived projects"
+// @Success 200 {object} []entity.Project
+// @Failure 400 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /projects [get]
+func (p *projectHandler) GetProjectsHandler(w http.ResponseWriter, r *http.Request) {
+	var archived *bool
+
+	archivedString := r.URL.Query().Get("archived")
+	if archivedString != "" {
+		value, err := strconv.ParseBool(archivedString)
+		if err != nil {
+			p.log.Error("Not correct query result")
+			QueryError(w)
+			return
+		}
+		archived = &value
+	}
+
+	userID := getUserID(r.Context())
+
+	projects, err := p.projectUsecase.GetUserProjects(context.Background(), userID, archived)
+	if err != nil {
+		p.log.Error("projectUsecase.GetUserProjects: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(projects)
+}
+
+// CreateProjectHandler godoc
+// @Summary Create new project
+// @Tags Project
+// @Description create new project by userID from context, return created project
+// @Accept json
+// @Produce json
+// @Param input body ProjectRequest true "project attribute"
+// @Success 201 {object} entity.Project
+// @Failure 400 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /projects/add [post]
+func (p *projectHandler) CreateProjectHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(ProjectRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		p.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	createdProject, err := p.projectUsecase.CreateProject(context.Background(), &entity.Project{UserID: userID, Name: data.Name})
+	if err != nil {
+		p.log.Error("projectUsecase.CreateProject: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(createdProject)
+}
+
+// RenameProjectHandler godoc
+// @Summary Rename project
+// @Tags Project
+// @Description rename project of user from context, return updated project
+// @Accept json
+// @Produce json
+// @Param id path int true "project id"
+// @Param input body ProjectRequest true "project attribute"
+// @Success 200 {object} entity.Project
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /projects/{id} [put]
+func (p *projectHandler) RenameProjectHandler(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := p.ownedProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	data := new(ProjectRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		p.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	updatedProject, err := p.projectUsecase.RenameProject(context.Background(), projectID, data.Name)
+	if err != nil {
+		p.log.Error("projectUsecase.RenameProject: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(updatedProject)
+}
+
+// ArchiveProjectHandler godoc
+// @Summary Archive project
+// @Tags Project
+// @Description archive or restore project of user from context, tasks can not be moved into archived project
+// @Accept json
+// @Produce json
+// @Param id path int true "project id"
+// @Param input body ArchiveRequest true "archive flag"
+// @Success 200 {object} entity.Project
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /projects/{id}/archive [put]
+func (p *projectHandler) ArchiveProjectHandler(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := p.ownedProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	data := new(ArchiveRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		p.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	updatedProject, err := p.projectUsecase.ArchiveProject(context.Background(), projectID, data.Archived)
+	if err != nil {
+		p.log.Error("projectUsecase.ArchiveProject: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(updatedProject)
+}
+
+// DeleteProjectHandler godoc
+// @Summary Delete project
+// @Tags Project
+// @Description delete project by id, its tasks are kept without project
+// @Accept json
+// @Produce json
+// @Param id path int true "project id"
+// @Success 204
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /projects/{id} [delete]
+func (p *projectHandler) DeleteProjectHandler(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := p.ownedProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := p.projectUsecase.DeleteProject(context.Background(), projectID); err != nil {
+		p.log.Error("projectUsecase.DeleteProject: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetProjectTasksHandler godoc
+// @Summary Get project tasks
+// @Tags Project
+// @Description get project tasks with the same pagination and filters as GET /tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "project id"
+// @Param page query int false "page number starting from 1, without page the whole list is returned" Format(page)
+// @Param page_size query int false "tasks per page, 3 by default, at most 100" Format(page_size)
+// @Param q query string false "full-text filter over header and description"
+// @Param after query string false "cursor from next_cursor, switches to cursor pagination, empty value returns the first page" Format(cursor)
+// @Param before query string false "cursor from prev_cursor, switches to cursor pagination" Format(cursor)
+// @Param datetime query string false "exact start date and time of tasks, 31.12.2023 18:00" Format(datetime)
+// @Param start_from query string false "start date from, inclusive: 31.12.2023, 31.12.2023 18:00 or ISO 8601" Format(datetime)
+// @Param start_to query string false "start date to, inclusive, date without time includes the whole day" Format(datetime)
+// @Param created_from query string false "creation date from, inclusive" Format(datetime)
+// @Param created_to query string false "creation date to, inclusive" Format(datetime)
+// @Param due_from query string false "due date from, inclusive" Format(datetime)
+// @Param due_to query string false "due date to, inclusive" Format(datetime)
+// @Param status query boolean false "task status" Format(status)
+// @Param priority query string false "task priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort field, id by default" Enums(id, priority, start_date, due_date, created_at, manual)
+// @Param order query string false "sort order, desc by default: newest and most urgent first, asc for manual order" Enums(asc, desc)
+// @Param due query string false "due date filter" Enums(overdue, today, week)
+// @Param tag query []int false "tag id, can be repeated" collectionFormat(multi)
+// @Param tag_mode query string false "match tasks with any or all of the tags, any by default" Enums(any, all)
+// @Success 200 {object} entity.TaskPage
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /projects/{id}/tasks [get]
+func (p *projectHandler) GetProjectTasksHandler(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := p.ownedProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	opt, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		p.log.Error("parseTaskQuery: %v", err)
+		paramOptionError(w, err)
+		return
+	}
+	opt.Filter.ProjectID = &projectID
+	opt.UserID = getUserID(r.Context())
+
+	tasks, err := p.taskUsecase.GetTask(context.Background(), opt)
+	if err != nil {
+		p.log.Error("taskUsecase.GetTask: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(tasks)
+}
+
+// GetProjectBoardHandler godoc
+// @Summary Get project board
+// @Tags Project
+// @Description get project tasks grouped by board columns, the project's own statuses are used if set, otherwise the user's.
+// @Description Accepts the same filters as GET /tasks/board
+// @Accept json
+// @Produce json
+// @Param id path int true "project id"
+// @Param q query string false "full-text filter over header and description"
+// @Param priority query string false "task priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort field inside a column, id by default" Enums(id, priority, start_date, due_date, created_at, manual)
+// @Param order query string false "sort order inside a column, desc by default" Enums(asc, desc)
+// @Param due query string false "due date filter" Enums(overdue, today, week)
+// @Param tag query []int false "tag id, can be repeated" collectionFormat(multi)
+// @Param tag_mode query string false "match tasks with any or all of the tags, any by default" Enums(any, all)
+// @Success 200 {object} entity.Board
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /projects/{id}/board [get]
+func (p *projectHandler) GetProjectBoardHandler(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := p.ownedProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	opt, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		p.log.Error("parseTaskQuery: %v", err)
+		paramOptionError(w, err)
+		return
+	}
+	if opt.IsPaged() {
+		QueryError(w)
+		return
+	}
+	opt.Filter.ProjectID = &projectID
+	opt.UserID = getUserID(r.Context())
+
+	board, err := p.taskUsecase.GetBoard(context.Background(), opt)
+	if err != nil {
+		p.log.Error("taskUsecase.GetBoard: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(board)
+}
+
+// ownedProjectID разбирает id проекта из пути и проверяет, что проект принадлежит пользователю
+func (p *projectHandler) ownedProjectID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	param := chi.URLParam(r, "id")
+	projectID, err := strconv.Atoi(param)
+	if err != nil {
+		p.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return 0, false
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := p.projectUsecase.IsEqualUserID(context.Background(), userID, projectID)
+	if err != nil {
+		p.log.Error("projectUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return 0, false
+	}
+
+	if !equal {
+		AccessError(w)
+		return 0, false
+	}
+
+	return projectID, true
+}
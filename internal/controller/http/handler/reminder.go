@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder"
+	"go-todolist-sber/internal/task"
+	"go-todolist-sber/pkg/logger"
+	"net/http"
+	"strconv"
+)
+
+type reminderHandler struct {
+	reminderUsecase reminder.ReminderUsecase
+	taskUsecase     task.TaskUsecase
+	log             *logger.Logger
+}
+
+func NewReminderHandler(reminderUsecase reminder.ReminderUsecase, taskUsecase task.TaskUsecase, log *logger.Logger) *reminderHandler {
+	return &reminderHandler{
+		reminderUsecase: reminderUsecase,
+		taskUsecase:     taskUsecase,
+		log:             log,
+	}
+}
+
+type ReminderRequest struct {
+	// RemindAt — абсолютное время напоминания, задается вместо anchor и offset
+	RemindAt string                `json:"remind_at" example:"31.12.2023 17:00"`
+	Anchor   entity.ReminderAnchor `json:"anchor" example:"due" enums:"start,due"`
+	// Offset — за сколько минут до даты anchor сработает напоминание
+	Offset int `json:"offset" example:"60"`
+}
+
+// GetRemindersHandler godoc
+// @Summary Get task reminders
+// @Tags Reminder
+// @Description get reminders of task, sent reminders have sent_at
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Success 200 {object} []entity.Reminder
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/reminders [get]
+func (h *reminderHandler) GetRemindersHandler(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := h.ownedTaskID(w, r)
+	if !ok {
+		return
+	}
+
+	reminders, err := h.reminderUsecase.GetTaskReminders(context.Background(), taskID)
+	if err != nil {
+		h.log.Error("reminderUsecase.GetTaskReminders: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(reminders)
+}
+
+// CreateReminderHandler godoc
+// @Summary Create task reminder
+// @Tags Reminder
+// @Description create reminder at absolute time or offset minutes before start or due date of task, return created reminder
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Param input body ReminderRequest true "reminder attribute"
+// @Success 201 {object} entity.Reminder
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/reminders [post]
+func (h *reminderHandler) CreateReminderHandler(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := h.ownedTaskID(w, r)
+	if !ok {
+		return
+	}
+
+	data := new(ReminderRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		h.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	remindAt, _, err := parseDueDate(data.RemindAt)
+	if err != nil {
+		h.log.Error("parseDueDate: %v", err)
+		ParseTimeError(w)
+		return
+	}
+
+	reminder := &entity.Reminder{
+		TaskID:   taskID,
+		UserID:   getUserID(r.Context()),
+		RemindAt: remindAt,
+		Anchor:   data.Anchor,
+		Offset:   data.Offset,
+	}
+
+	createdReminder, err := h.reminderUsecase.CreateReminder(context.Background(), reminder)
+	if err != nil {
+		h.log.Error("reminderUsecase.CreateReminder: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(createdReminder)
+}
+
+// DeleteReminderHandler godoc
+// @Summary Delete reminder
+// @Tags Reminder
+// @Description delete reminder by id
+// @Accept json
+// @Produce json
+// @Param id path int true "reminder id"
+// @Success 204
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /reminders/{id} [delete]
+func (h *reminderHandler) DeleteReminderHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	reminderID, err := strconv.Atoi(param)
+	if err != nil {
+		h.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := h.reminderUsecase.IsEqualUserID(context.Background(), userID, reminderID)
+	if err != nil {
+		h.log.Error("reminderUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	if err := h.reminderUsecase.DeleteReminder(context.Background(), reminderID); err != nil {
+		h.log.Error("reminderUsecase.DeleteReminder: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ownedTaskID разбирает id задачи из пути и проверяет, что задача принадлежит пользователю
+func (h *reminderHandler) ownedTaskID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	param := chi.URLParam(r, "id")
+	taskID, err := strconv.Atoi(param)
+	if err != nil {
+		h.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return 0, false
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := h.taskUsecase.IsEqualUserID(context.Background(), userID, taskID)
+	if err != nil {
+		h.log.Error("taskUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return 0, false
+	}
+
+	if !equal {
+		AccessError(w)
+		return 0, false
+	}
+
+	return taskID, true
+}
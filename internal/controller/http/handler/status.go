@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/status"
+	"go-todolist-sber/internal/task"
+	"go-todolist-sber/pkg/logger"
+	"net/http"
+	"strconv"
+)
+
+type statusHandler struct {
+	statusUsecase status.StatusUsecase
+	taskUsecase   task.TaskUsecase
+	log           *logger.Logger
+}
+
+func NewStatusHandler(statusUsecase status.StatusUsecase, taskUsecase task.TaskUsecase, log *logger.Logger) *statusHandler {
+	return &statusHandler{
+		statusUsecase: statusUsecase,
+		taskUsecase:   taskUsecase,
+		log:           log,
+	}
+}
+
+type BoardStatusRequest struct {
+	Name string `json:"name" example:"in_progress"`
+	// Position — место колонки на доске, без него новая колонка добавляется в конец, а при изменении место сохраняется
+	Position *int `json:"position"`
+	// Done делает колонку завершающей: задачи в ней считаются выполненными, при изменении без поля признак сохраняется
+	Done *bool `json:"done"`
+	// ProjectID задает колонку проекта, учитывается только при создании
+	ProjectID *int `json:"project_id"`
+	// Transitions — id колонок, в которые можно перевести задачу, пустой список разрешает любой переход,
+	// при изменении без поля переходы сохраняются
+	Transitions []int `json:"transitions"`
+}
+
+// GetStatusesHandler godoc
+// @Summary Get board columns
+// @Tags Status
+// @Description get board columns in order: the project's own statuses if project_id is set and the project has them,
+// @Description otherwise the user's statuses. Default columns backlog, todo, in_progress, review and done are created on first use
+// @Accept json
+// @Produce json
+// @Param project_id query int false "project id"
+// @Success 200 {object} []entity.Status
+// @Failure 400 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /statuses [get]
+func (s *statusHandler) GetStatusesHandler(w http.ResponseWriter, r *http.Request) {
+	var projectID *int
+	if param := r.URL.Query().Get("project_id"); param != "" {
+		id, err := strconv.Atoi(param)
+		if err != nil {
+			s.log.Error("strconv.Atoi: %v", err)
+			QueryError(w)
+			return
+		}
+		projectID = &id
+	}
+
+	userID := getUserID(r.Context())
+
+	statuses, err := s.statusUsecase.GetWorkflow(context.Background(), userID, projectID)
+	if err != nil {
+		s.log.Error("statusUsecase.GetWorkflow: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(statuses)
+}
+
+// CreateStatusHandler godoc
+// @Summary Create board column
+// @Tags Status
+// @Description create user column or, with project_id, project column. Name is unique within the user or project columns,
+// @Description transitions must refer to columns of the same set
+// @Accept json
+// @Produce json
+// @Param input body BoardStatusRequest true "column attribute"
+// @Success 201 {object} entity.Status
+// @Failure 400 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /statuses/add [post]
+func (s *statusHandler) CreateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(BoardStatusRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		s.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	createdStatus, err := s.statusUsecase.CreateStatus(context.Background(), &entity.Status{
+		UserID:      userID,
+		ProjectID:   data.ProjectID,
+		Name:        data.Name,
+		Position:    data.Position,
+		Done:        data.Done != nil && *data.Done,
+		Transitions: data.Transitions,
+	})
+	if err != nil {
+		s.log.Error("statusUsecase.CreateStatus: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(createdStatus)
+}
+
+// UpdateStatusHandler godoc
+// @Summary Update board column
+// @Tags Status
+// @Description update column name, position, done flag and allowed transitions, return updated column.
+// @Description Without done the flag is kept; changing it completes or reopens the column tasks one by one with audit entries
+// @Accept json
+// @Produce json
+// @Param id path int true "column id"
+// @Param input body BoardStatusRequest true "column attribute"
+// @Success 200 {object} entity.Status
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /statuses/{id} [put]
+func (s *statusHandler) UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	statusID, err := strconv.Atoi(param)
+	if err != nil {
+		s.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	data := new(BoardStatusRequest)
+	d := json.NewDecoder(r.Body)
+	err = d.Decode(&data)
+	if err != nil {
+		s.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := s.statusUsecase.IsEqualUserID(context.Background(), userID, statusID)
+	if err != nil {
+		s.log.Error("statusUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	updatedStatus, err := s.taskUsecase.UpdateColumn(context.Background(), &entity.Status{
+		ID:          statusID,
+		UserID:      userID,
+		Name:        data.Name,
+		Position:    data.Position,
+		Transitions: data.Transitions,
+	}, data.Done)
+	if err != nil {
+		s.log.Error("taskUsecase.UpdateColumn: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(updatedStatus)
+}
+
+// DeleteStatusHandler godoc
+// @Summary Delete board column
+// @Tags Status
+// @Description delete column by id, its tasks move to the default column
+// @Accept json
+// @Produce json
+// @Param id path int true "column id"
+// @Success 204
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /statuses/{id} [delete]
+func (s *statusHandler) DeleteStatusHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	statusID, err := strconv.Atoi(param)
+	if err != nil {
+		s.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := s.statusUsecase.IsEqualUserID(context.Background(), userID, statusID)
+	if err != nil {
+		s.log.Error("statusUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	if err := s.statusUsecase.DeleteStatus(context.Background(), statusID); err != nil {
+		s.log.Error("statusUsecase.DeleteStatus: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/tag"
+	"go-todolist-sber/pkg/logger"
+	"net/http"
+	"strconv"
+)
+
+type tagHandler struct {
+	tagUsecase tag.TagUsecase
+	log        *logger.Logger
+}
+
+func NewTagHandler(tagUsecase tag.TagUsecase, log *logger.Logger) *tagHandler {
+	return &tagHandler{
+		tagUsecase: tagUsecase,
+		log:        log,
+	}
+}
+
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+// GetTagsHandler godoc
+// @Summary Get user tags
+// @Tags Tag
+// @Description get all tags of user from context
+// @Accept json
+// @Produce json
+// @Success 200 {object} []entity.Tag
+// @Failure 500 {object} JSONError
+// @Router /tags [get]
+func (t *tagHandler) GetTagsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := getUserID(r.Context())
+
+	tags, err := t.tagUsecase.GetUserTags(context.Background(), userID)
+	if err != nil {
+		t.log.Error("tagUsecase.GetUserTags: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(tags)
+}
+
+// CreateTagHandler godoc
+// @Summary Create new tag
+// @Tags Tag
+// @Description create new user tag by userID from context, tag name is unique for user
+// @Accept json
+// @Produce json
+// @Param input body TagRequest true "tag attribute"
+// @Success 201 {object} entity.Tag
+// @Failure 400 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tags/add [post]
+func (t *tagHandler) CreateTagHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(TagRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	createdTag, err := t.tagUsecase.CreateTag(context.Background(), &entity.Tag{UserID: userID, Name: data.Name})
+	if err != nil {
+		t.log.Error("tagUsecase.CreateTag: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(createdTag)
+}
+
+// UpdateTagHandler godoc
+// @Summary Rename tag
+// @Tags Tag
+// @Description rename user tag, return updated tag
+// @Accept json
+// @Produce json
+// @Param id path int true "tag id"
+// @Param input body TagRequest true "tag attribute"
+// @Success 200 {object} entity.Tag
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tags/{id} [put]
+func (t *tagHandler) UpdateTagHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	tagID, err := strconv.Atoi(param)
+	if err != nil {
+		t.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	data := new(TagRequest)
+	d := json.NewDecoder(r.Body)
+	err = d.Decode(&data)
+	if err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := t.tagUsecase.IsEqualUserID(context.Background(), userID, tagID)
+	if err != nil {
+		t.log.Error("tagUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	updatedTag, err := t.tagUsecase.UpdateTag(context.Background(), &entity.Tag{ID: tagID, UserID: userID, Name: data.Name})
+	if err != nil {
+		t.log.Error("tagUsecase.UpdateTag: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(updatedTag)
+}
+
+// DeleteTagHandler godoc
+// @Summary Delete tag
+// @Tags Tag
+// @Description delete tag by id, tag is detached from all tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "tag id"
+// @Success 204
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tags/{id} [delete]
+func (t *tagHandler) DeleteTagHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	tagID, err := strconv.Atoi(param)
+	if err != nil {
+		t.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := t.tagUsecase.IsEqualUserID(context.Background(), userID, tagID)
+	if err != nil {
+		t.log.Error("tagUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	if err := t.tagUsecase.DeleteTag(context.Background(), tagID); err != nil {
+		t.log.Error("tagUsecase.DeleteTag: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,15 +1,23 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"go-todolist-sber/internal/apperror"
 	"go-todolist-sber/internal/entity"
 	"go-todolist-sber/internal/task"
+	"go-todolist-sber/pkg/jsonpatch"
 	"go-todolist-sber/pkg/logger"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -26,13 +34,155 @@ func NewTaskHandler(taskUsecase task.TaskUsecase, log *logger.Logger) *taskHandl
 }
 
 type TaskRequest struct {
-	Header      string    `json:"header"`
-	Description string    `json:"description"`
-	StartDate   time.Time `json:"start_date"`
+	Header      string          `json:"header"`
+	Description string          `json:"description"`
+	StartDate   time.Time       `json:"start_date"`
+	Priority    entity.Priority `json:"priority" enums:"none,low,medium,high,urgent"`
+	// DueDate принимает дату без времени или дату со временем
+	DueDate string `json:"due_date" example:"31.12.2023 18:00"`
+	// Tags заменяет набор меток задачи, если поле не передано, метки не меняются
+	Tags []int `json:"tags"`
+	// ProjectID учитывается только при создании, для переноса задачи используется PUT /tasks/{id}/project
+	ProjectID *int `json:"project_id"`
+	// ParentID делает задачу подзадачей, учитывается только при создании
+	ParentID *int `json:"parent_id"`
+	// RRule — правило повторения RFC 5545
+	RRule string `json:"rrule" example:"FREQ=WEEKLY;BYDAY=MO,WE,FR"`
+}
+
+type BulkRequest struct {
+	Action entity.BulkAction `json:"action" enums:"complete,reopen,delete,add_tags,remove_tags,move"`
+	// IDs — задачи операции, без них операция применяется к задачам по фильтру из параметров запроса
+	IDs []int `json:"ids"`
+	// Tags — метки для add_tags и remove_tags
+	Tags []int `json:"tags"`
+	// ProjectID — проект для move, null выносит задачи из проекта
+	ProjectID *int `json:"project_id"`
+}
+
+// TaskPatch — документ задачи, к которому применяется патч. Отсутствующее поле или null сбрасывает значение:
+// описание и правило повторения становятся пустыми, срок, проект и родитель снимаются, метки очищаются.
+// Заголовок, дату начала, приоритет и статус сбросить нельзя
+type TaskPatch struct {
+	Header      *string          `json:"header"`
+	Description *string          `json:"description"`
+	StartDate   *time.Time       `json:"start_date"`
+	Priority    *entity.Priority `json:"priority" enums:"none,low,medium,high,urgent"`
+	// DueDate принимает дату без времени или дату со временем
+	DueDate   *string `json:"due_date" example:"2023-12-31T18:00:00"`
+	Done      *bool   `json:"done"`
+	Tags      []int   `json:"tags"`
+	ProjectID *int    `json:"project_id"`
+	ParentID  *int    `json:"parent_id"`
+	RRule     *string `json:"rrule" example:"FREQ=WEEKLY;BYDAY=MO,WE,FR"`
+}
+
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+// newTaskPatch строит документ для патча из текущего состояния задачи
+func newTaskPatch(task *entity.Task) *TaskPatch {
+	patch := &TaskPatch{
+		Header:      &task.Header,
+		Description: &task.Description,
+		StartDate:   &task.StartDate,
+		Priority:    &task.Priority,
+		Done:        &task.Done,
+		Tags:        make([]int, 0, len(task.Tags)),
+		ProjectID:   task.ProjectID,
+		ParentID:    task.ParentID,
+		RRule:       &task.RRule,
+	}
+	if task.DueDate != nil {
+		layout := "2006-01-02"
+		if task.DueHasTime {
+			layout = "2006-01-02T15:04:05"
+		}
+		dueDate := task.DueDate.Format(layout)
+		patch.DueDate = &dueDate
+	}
+	for _, tag := range task.Tags {
+		patch.Tags = append(patch.Tags, tag.ID)
+	}
+	return patch
+}
+
+// apply переносит документ после патча в задачу, сброшенные поля получают пустые значения
+func (p *TaskPatch) apply(task *entity.Task) error {
+	if p.Header == nil || p.StartDate == nil || p.Priority == nil || p.Done == nil {
+		return apperror.ErrDataNotValid
+	}
+
+	task.Header = *p.Header
+	task.StartDate = *p.StartDate
+	task.Priority = *p.Priority
+	task.Done = *p.Done
+	task.Description = ""
+	if p.Description != nil {
+		task.Description = *p.Description
+	}
+	task.RRule = ""
+	if p.RRule != nil {
+		task.RRule = *p.RRule
+	}
+
+	task.DueDate, task.DueHasTime = nil, false
+	if p.DueDate != nil {
+		dueDate, dueHasTime, err := parseDueDate(*p.DueDate)
+		if err != nil {
+			return err
+		}
+		task.DueDate, task.DueHasTime = dueDate, dueHasTime
+	}
+
+	task.TagIDs = p.Tags
+	if task.TagIDs == nil {
+		task.TagIDs = []int{}
+	}
+	task.ProjectID = p.ProjectID
+	task.ParentID = p.ParentID
+	return nil
 }
 
 type StatusRequest struct {
-	Status bool `json:"status"`
+	// Status — true или false для отметки о выполнении либо имя колонки доски
+	Status StatusValue `json:"status" swaggertype:"string" example:"in_progress"`
+	// Cascade применяет статус ко всем подзадачам, для колонки доски не используется
+	Cascade bool `json:"cascade"`
+}
+
+// StatusValue содержит либо признак выполнения Done, либо имя колонки Name
+type StatusValue struct {
+	Done *bool
+	Name string
+}
+
+func (v *StatusValue) UnmarshalJSON(data []byte) error {
+	var done bool
+	if err := json.Unmarshal(data, &done); err == nil {
+		v.Done = &done
+		return nil
+	}
+	return json.Unmarshal(data, &v.Name)
+}
+
+const (
+	scopeThis   = "this"
+	scopeSeries = "series"
+)
+
+type MoveRequest struct {
+	// ProjectID равный null выносит задачу из проекта
+	ProjectID *int `json:"project_id"`
+}
+
+type ReorderRequest struct {
+	// After — задача, после которой встает перемещаемая, Before — задача, перед которой она встает.
+	// Достаточно одного соседа
+	After  *int `json:"after"`
+	Before *int `json:"before"`
 }
 
 // GetTaskHandler godoc
@@ -41,55 +191,153 @@ type StatusRequest struct {
 // @Description get user task with pagination and filter, by default without parameters return first page
 // @Accept json
 // @Produce json
-// @Param page query int false "page number" Format(page)
-// @Param datetime query string false "date and time required tasks" Format(datetime)
+// @Param page query int false "page number starting from 1, without page the whole list is returned" Format(page)
+// @Param page_size query int false "tasks per page, 3 by default, at most 100" Format(page_size)
+// @Param q query string false "full-text filter over header and description, combines with all other filters"
+// @Param after query string false "cursor from next_cursor, switches to cursor pagination, empty value returns the first page" Format(cursor)
+// @Param before query string false "cursor from prev_cursor, switches to cursor pagination" Format(cursor)
+// @Param datetime query string false "exact start date and time of tasks, 31.12.2023 18:00" Format(datetime)
+// @Param start_from query string false "start date from, inclusive: 31.12.2023, 31.12.2023 18:00 or ISO 8601" Format(datetime)
+// @Param start_to query string false "start date to, inclusive, date without time includes the whole day" Format(datetime)
+// @Param created_from query string false "creation date from, inclusive" Format(datetime)
+// @Param created_to query string false "creation date to, inclusive" Format(datetime)
+// @Param due_from query string false "due date from, inclusive" Format(datetime)
+// @Param due_to query string false "due date to, inclusive" Format(datetime)
 // @Param status query boolean false "task status" Format(status)
-// @Success 200 {object} []entity.Task
+// @Param priority query string false "task priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort field, id by default" Enums(id, priority, start_date, due_date, created_at, manual)
+// @Param order query string false "sort order, desc by default: newest and most urgent first, asc for manual order" Enums(asc, desc)
+// @Param due query string false "due date filter" Enums(overdue, today, week)
+// @Param tag query []int false "tag id, can be repeated" collectionFormat(multi)
+// @Param tag_mode query string false "match tasks with any or all of the tags, any by default" Enums(any, all)
+// @Param project_id query int false "project id"
+// @Success 200 {object} entity.TaskPage
 // @Failure 400 {object} JSONError
 // @Failure 404 {object} JSONError
 // @Failure 500 {object} JSONError
 // @Router /tasks [get]
 func (t *taskHandler) GetTaskHandler(w http.ResponseWriter, r *http.Request) {
-	opt := new(entity.ParamOption)
+	opt, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		t.log.Error("parseTaskQuery: %v", err)
+		paramOptionError(w, err)
+		return
+	}
 
-	pageString := r.URL.Query().Get("page")
-	if pageString != "" {
-		page, err := strconv.Atoi(pageString)
-		if err != nil {
-			t.log.Error("Not correct query result")
-			QueryError(w)
-			return
-		}
-		opt.Page = page
+	opt.UserID = getUserID(r.Context())
+
+	tasks, err := t.taskUsecase.GetTask(context.Background(), opt)
+	if err != nil {
+		t.log.Error("taskUsecase.GetTaskWithPaginationAndFilter: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
 	}
 
-	datetime := r.URL.Query().Get("datetime")
-	if datetime != "" {
-		parsedDate, err := time.Parse("02.01.2006 15:04", datetime)
-		if err != nil {
-			t.log.Error("time.Parse: %v", err)
-			ParseTimeError(w)
-			return
-		}
-		opt.DateTime = parsedDate
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(tasks)
+}
+
+// SearchTaskHandler godoc
+// @Summary Search user tasks
+// @Tags Task
+// @Description full-text search over task header and description, most relevant first, matches in headline and snippet are wrapped in <mark>. Query supports quoted phrases, OR and -word. Composes with the same pagination and filters as GET /tasks
+// @Accept json
+// @Produce json
+// @Param q query string true "search query"
+// @Param page query int false "page number starting from 1, without page the whole list is returned" Format(page)
+// @Param page_size query int false "tasks per page, 3 by default, at most 100" Format(page_size)
+// @Param datetime query string false "exact start date and time of tasks, 31.12.2023 18:00" Format(datetime)
+// @Param start_from query string false "start date from, inclusive: 31.12.2023, 31.12.2023 18:00 or ISO 8601" Format(datetime)
+// @Param start_to query string false "start date to, inclusive, date without time includes the whole day" Format(datetime)
+// @Param created_from query string false "creation date from, inclusive" Format(datetime)
+// @Param created_to query string false "creation date to, inclusive" Format(datetime)
+// @Param due_from query string false "due date from, inclusive" Format(datetime)
+// @Param due_to query string false "due date to, inclusive" Format(datetime)
+// @Param status query boolean false "task status" Format(status)
+// @Param priority query string false "task priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort field for equally relevant tasks" Enums(id, priority, start_date, due_date, created_at, manual)
+// @Param order query string false "sort order for equally relevant tasks, desc by default" Enums(asc, desc)
+// @Param due query string false "due date filter" Enums(overdue, today, week)
+// @Param tag query []int false "tag id, can be repeated" collectionFormat(multi)
+// @Param tag_mode query string false "match tasks with any or all of the tags, any by default" Enums(any, all)
+// @Param project_id query int false "project id"
+// @Success 200 {object} entity.SearchPage
+// @Failure 400 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/search [get]
+func (t *taskHandler) SearchTaskHandler(w http.ResponseWriter, r *http.Request) {
+	opt, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		t.log.Error("parseTaskQuery: %v", err)
+		paramOptionError(w, err)
+		return
+	}
+	if opt.CursorMode {
+		QueryError(w)
+		return
 	}
 
-	statusString := r.URL.Query().Get("status")
-	if statusString != "" {
-		status, err := strconv.ParseBool(statusString)
-		if err != nil || statusString == "" {
-			t.log.Error("Not correct query result")
-			QueryError(w)
-			return
-		}
-		opt.Status = &status
+	opt.UserID = getUserID(r.Context())
+
+	results, err := t.taskUsecase.SearchTasks(context.Background(), opt)
+	if err != nil {
+		t.log.Error("taskUsecase.SearchTasks: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
 	}
 
-	userID := getUserID(r.Context())
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(results)
+}
+
+// GetBoardHandler godoc
+// @Summary Get task board
+// @Tags Task
+// @Description get user tasks grouped by board columns in column order, tasks inside a column follow sort and order.
+// @Description With project_id the project columns are used if the project has its own statuses. Accepts the same filters as GET /tasks without pagination
+// @Accept json
+// @Produce json
+// @Param q query string false "full-text filter over header and description"
+// @Param start_from query string false "start date from, inclusive: 31.12.2023, 31.12.2023 18:00 or ISO 8601" Format(datetime)
+// @Param start_to query string false "start date to, inclusive, date without time includes the whole day" Format(datetime)
+// @Param created_from query string false "creation date from, inclusive" Format(datetime)
+// @Param created_to query string false "creation date to, inclusive" Format(datetime)
+// @Param due_from query string false "due date from, inclusive" Format(datetime)
+// @Param due_to query string false "due date to, inclusive" Format(datetime)
+// @Param priority query string false "task priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort field inside a column, id by default" Enums(id, priority, start_date, due_date, created_at, manual)
+// @Param order query string false "sort order inside a column, desc by default" Enums(asc, desc)
+// @Param due query string false "due date filter" Enums(overdue, today, week)
+// @Param tag query []int false "tag id, can be repeated" collectionFormat(multi)
+// @Param tag_mode query string false "match tasks with any or all of the tags, any by default" Enums(any, all)
+// @Param project_id query int false "project id"
+// @Success 200 {object} entity.Board
+// @Failure 400 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/board [get]
+func (t *taskHandler) GetBoardHandler(w http.ResponseWriter, r *http.Request) {
+	opt, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		t.log.Error("parseTaskQuery: %v", err)
+		paramOptionError(w, err)
+		return
+	}
+	if opt.IsPaged() {
+		QueryError(w)
+		return
+	}
 
-	tasks, err := t.taskUsecase.GetTask(context.Background(), userID, opt)
+	opt.UserID = getUserID(r.Context())
+
+	board, err := t.taskUsecase.GetBoard(context.Background(), opt)
 	if err != nil {
-		t.log.Error("taskUsecase.GetTaskWithPaginationAndFilter: %v", err)
+		t.log.Error("taskUsecase.GetBoard: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
 		return
 	}
@@ -97,19 +345,23 @@ func (t *taskHandler) GetTaskHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	e := json.NewEncoder(w)
 	e.SetIndent(" ", " ")
-	e.Encode(tasks)
+	e.Encode(board)
 }
 
 // CreateTaskHandler godoc
 // @Summary Create new task
 // @Tags Task
-// @Description create new user task by userID from context, return created task
+// @Description create new user task by userID from context, tags, project and parent task must belong to the user, return created task
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "unique key of the request, a retry with the same key and body returns the stored response"
 // @Param input body TaskRequest true "task attribute"
 // @Success 201 {object} entity.Task
+// @Header 201 {string} Idempotent-Replayed "true when the stored response is replayed"
 // @Failure 400 {object} JSONError
 // @Failure 404 {object} JSONError
+// @Failure 409 {object} JSONError
+// @Failure 422 {object} JSONError
 // @Failure 500 {object} JSONError
 // @Router /tasks/add [post]
 func (t *taskHandler) CreateTaskHandler(w http.ResponseWriter, r *http.Request) {
@@ -122,21 +374,36 @@ func (t *taskHandler) CreateTaskHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	dueDate, dueHasTime, err := parseDueDate(data.DueDate)
+	if err != nil {
+		t.log.Error("parseDueDate: %v", err)
+		ParseTimeError(w)
+		return
+	}
+
 	userID := getUserID(r.Context())
 
 	task := &entity.Task{
 		Header:      data.Header,
 		Description: data.Description,
 		StartDate:   data.StartDate,
+		Priority:    data.Priority,
+		DueDate:     dueDate,
+		DueHasTime:  dueHasTime,
+		TagIDs:      data.Tags,
+		ProjectID:   data.ProjectID,
+		ParentID:    data.ParentID,
+		RRule:       data.RRule,
 		UserID:      userID,
 	}
-	createdTask, err := t.taskUsecase.CreateTask(context.Background(), task)
+	createdTask, err := t.taskUsecase.CreateTask(r.Context(), task)
 	if err != nil {
 		t.log.Error("taskUsecase.CreateTask: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
 		return
 	}
 
+	setETag(w, createdTask)
 	w.WriteHeader(http.StatusCreated)
 	e := json.NewEncoder(w)
 	e.SetIndent(" ", " ")
@@ -146,11 +413,14 @@ func (t *taskHandler) CreateTaskHandler(w http.ResponseWriter, r *http.Request)
 // DeleteTaskHandler godoc
 // @Summary Delete task
 // @Tags Task
-// @Description delete task by id
+// @Description move task to trash by id, all its subtasks are moved too. Tasks in trash are purged after the retention period.
+// @Description With permanent=true the task is deleted at once, also from trash
 // @Accept json
 // @Produce json
 // @Param id path int true "task id"
+// @Param permanent query boolean false "delete without trash, false by default"
 // @Success 204
+// @Failure 400 {object} JSONError
 // @Failure 403 {object} JSONError
 // @Failure 404 {object} JSONError
 // @Failure 500 {object} JSONError
@@ -164,8 +434,31 @@ func (t *taskHandler) DeleteTaskHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	permanent := false
+	if permanentString := r.URL.Query().Get("permanent"); permanentString != "" {
+		permanent, err = strconv.ParseBool(permanentString)
+		if err != nil {
+			t.log.Error("Not correct query result")
+			QueryError(w)
+			return
+		}
+	}
+
 	userID := getUserID(r.Context())
 
+	// задача в корзине не видна проверке владельца, поэтому окончательное удаление
+	// ограничивается задачами пользователя в самом запросе
+	if permanent {
+		if err := t.taskUsecase.DeleteTaskPermanently(r.Context(), taskID, userID); err != nil {
+			t.log.Error("taskUsecase.DeleteTaskPermanently: %v", err)
+			HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	equal, err := t.taskUsecase.IsEqualUserID(context.Background(), userID, taskID)
 	if err != nil {
 		t.log.Error("taskUsecase.IsEqualUserID: %v", err)
@@ -178,7 +471,7 @@ func (t *taskHandler) DeleteTaskHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := t.taskUsecase.DeleteTask(context.Background(), taskID); err != nil {
+	if err := t.taskUsecase.DeleteTask(r.Context(), taskID); err != nil {
 		t.log.Error("taskUsecase.DeleteTask: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
 		return
@@ -187,18 +480,105 @@ func (t *taskHandler) DeleteTaskHandler(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetTrashHandler godoc
+// @Summary Get tasks in trash
+// @Tags Task
+// @Description get user tasks from trash with the same pagination and filters as GET /tasks
+// @Accept json
+// @Produce json
+// @Param page query int false "page number starting from 1, without page the whole list is returned" Format(page)
+// @Param page_size query int false "tasks per page, 3 by default, at most 100" Format(page_size)
+// @Param q query string false "full-text filter over header and description"
+// @Param after query string false "cursor from next_cursor, switches to cursor pagination, empty value returns the first page" Format(cursor)
+// @Param before query string false "cursor from prev_cursor, switches to cursor pagination" Format(cursor)
+// @Param sort query string false "sort field, id by default" Enums(id, priority, start_date, due_date, created_at, manual)
+// @Param order query string false "sort order, desc by default" Enums(asc, desc)
+// @Param project_id query int false "project id"
+// @Success 200 {object} entity.TaskPage
+// @Failure 400 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/trash [get]
+func (t *taskHandler) GetTrashHandler(w http.ResponseWriter, r *http.Request) {
+	opt, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		t.log.Error("parseTaskQuery: %v", err)
+		paramOptionError(w, err)
+		return
+	}
+
+	opt.UserID = getUserID(r.Context())
+	opt.Filter.Deleted = true
+
+	tasks, err := t.taskUsecase.GetTask(context.Background(), opt)
+	if err != nil {
+		t.log.Error("taskUsecase.GetTask: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(tasks)
+}
+
+// RestoreTaskHandler godoc
+// @Summary Restore task from trash
+// @Tags Task
+// @Description restore task from trash together with subtasks deleted with it, deleted parent tasks are restored too, return restored task
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Success 200 {object} entity.Task
+// @Failure 400 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/restore [post]
+func (t *taskHandler) RestoreTaskHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	taskID, err := strconv.Atoi(param)
+	if err != nil {
+		t.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	task, err := t.taskUsecase.RestoreTask(r.Context(), taskID, userID)
+	if err != nil {
+		t.log.Error("taskUsecase.RestoreTask: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	setETag(w, task)
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(task)
+}
+
 // UpdateTaskHandler godoc
 // @Summary Update task
 // @Tags Task
-// @Description Update header, description, datetime, task status by userID from context, return updated task
+// @Description Update header, description, datetime, priority, due date, tags, recurrence rule by userID from context, return updated task.
+// @Description With scope=series header, description, priority and recurrence rule are applied to every not completed occurrence of the series.
+// @Description If-Match must hold the task ETag, on conflict 412 is returned with the current task and its ETag
 // @Accept json
 // @Produce json
 // @Param id path int true "task id"
+// @Param If-Match header string true "task ETag from a previous response, * skips the check"
+// @Param scope query string false "edit only this occurrence or the whole series, this by default" Enums(this, series)
 // @Param input body TaskRequest true "task attribute"
 // @Success 200 {object} entity.Task
+// @Header 200 {string} ETag "task version"
 // @Failure 400 {object} JSONError
 // @Failure 403 {object} JSONError
 // @Failure 404 {object} JSONError
+// @Failure 412 {object} entity.Task
+// @Failure 422 {object} JSONError
+// @Failure 428 {object} JSONError
 // @Failure 500 {object} JSONError
 // @Router /tasks/{id} [put]
 func (t *taskHandler) UpdateTaskHandler(w http.ResponseWriter, r *http.Request) {
@@ -210,6 +590,20 @@ func (t *taskHandler) UpdateTaskHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	scope := r.URL.Query().Get("scope")
+	if scope != "" && scope != scopeThis && scope != scopeSeries {
+		t.log.Error("Not correct query result")
+		QueryError(w)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		t.log.Error("parseIfMatch: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
 	data := new(TaskRequest)
 	d := json.NewDecoder(r.Body)
 	err = d.Decode(&data)
@@ -233,72 +627,75 @@ func (t *taskHandler) UpdateTaskHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	dueDate, dueHasTime, err := parseDueDate(data.DueDate)
+	if err != nil {
+		t.log.Error("parseDueDate: %v", err)
+		ParseTimeError(w)
+		return
+	}
+
 	task := &entity.Task{
 		Header:      data.Header,
 		Description: data.Description,
 		StartDate:   data.StartDate,
+		Priority:    data.Priority,
+		DueDate:     dueDate,
+		DueHasTime:  dueHasTime,
+		TagIDs:      data.Tags,
+		RRule:       data.RRule,
 		ID:          taskID,
 		UserID:      userID,
+		Version:     version,
 	}
-	updatedTask, err := t.taskUsecase.UpdateTask(context.Background(), task)
-	if err != nil {
-		t.log.Error("taskUsecase.UpdateTask: %v", err)
-		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
-	e := json.NewEncoder(w)
-	e.SetIndent(" ", " ")
-	e.Encode(updatedTask)
-}
 
-// GetAllTasksHandler godoc
-// @Summary Get all users task
-// @Tags Task
-// @Description Get all users tasks, available only to the admin
-// @Accept json
-// @Produce json
-// @Success 200 {object} []entity.Task
-// @Failure 403 {object} JSONError
-// @Failure 404 {object} JSONError
-// @Failure 500 {object} JSONError
-// @Router /tasks/all [get]
-func (t *taskHandler) GetAllTasksHandler(w http.ResponseWriter, r *http.Request) {
-	role := getRole(r.Context())
-	if role != "admin" {
-		AccessError(w)
+	var updatedTask *entity.Task
+	if scope == scopeSeries {
+		updatedTask, err = t.taskUsecase.UpdateTaskSeries(r.Context(), task)
+	} else {
+		updatedTask, err = t.taskUsecase.UpdateTask(r.Context(), task)
+	}
+	if errors.Is(err, apperror.ErrVersionMismatch) {
+		t.versionConflict(w, taskID)
 		return
 	}
-
-	tasks, err := t.taskUsecase.GetAllTasks(context.Background())
 	if err != nil {
-		t.log.Error("taskUsecase.GetAllTasks: %v", err)
+		t.log.Error("taskUsecase.UpdateTask: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
 		return
 	}
 
+	setETag(w, updatedTask)
 	w.WriteHeader(http.StatusOK)
 	e := json.NewEncoder(w)
 	e.SetIndent(" ", " ")
-	e.Encode(tasks)
+	e.Encode(updatedTask)
 }
 
-// UpdateStatusHandler godoc
-// @Summary Set status
+// PatchTaskHandler godoc
+// @Summary Patch task
 // @Tags Task
-// @Description Set to task completed or not by userID from context, return updated task
+// @Description Partially update task by userID from context, return updated task.
+// @Description Content-Type application/merge-patch+json (or application/json) applies JSON Merge Patch: null clears the field.
+// @Description Content-Type application/json-patch+json applies JSON Patch operations, a failed test operation returns 409.
+// @Description Description, due date, project, parent, tags and recurrence rule can be cleared, header, start date, priority and done can not.
+// @Description If-Match is optional, on conflict 412 is returned with the current task and its ETag
 // @Accept json
 // @Produce json
 // @Param id path int true "task id"
-// @Param input body StatusRequest true "task attribute"
+// @Param If-Match header string false "task ETag from a previous response"
+// @Param input body TaskPatch true "merge patch document or array of JSON Patch operations"
 // @Success 200 {object} entity.Task
+// @Header 200 {string} ETag "task version"
 // @Failure 400 {object} JSONError
 // @Failure 403 {object} JSONError
 // @Failure 404 {object} JSONError
+// @Failure 409 {object} JSONError
+// @Failure 412 {object} entity.Task
+// @Failure 415 {object} JSONError
+// @Failure 422 {object} JSONError
 // @Failure 500 {object} JSONError
-// @Router /tasks/{id}/status [put]
-func (t *taskHandler) UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
+// @Router /tasks/{id} [patch]
+func (t *taskHandler) PatchTaskHandler(w http.ResponseWriter, r *http.Request) {
 	param := chi.URLParam(r, "id")
 	taskID, err := strconv.Atoi(param)
 	if err != nil {
@@ -307,11 +704,22 @@ func (t *taskHandler) UpdateStatusHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	data := new(StatusRequest)
-	d := json.NewDecoder(r.Body)
-	err = d.Decode(&data)
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != mergePatchType && mediaType != jsonPatchType && mediaType != "application/json" {
+		ErrorJSON(w, "unsupported patch format", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil && !errors.Is(err, apperror.ErrVersionRequired) {
+		t.log.Error("parseIfMatch: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		t.log.Error("json.NewDecoder: %v", err)
+		t.log.Error("io.ReadAll: %v", err)
 		DecodingError(w)
 		return
 	}
@@ -330,33 +738,721 @@ func (t *taskHandler) UpdateStatusHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	updatedTask, err := t.taskUsecase.UpdateTaskStatus(context.Background(), data.Status, taskID)
+	task, err := t.taskUsecase.GetTaskByID(context.Background(), taskID)
 	if err != nil {
-		t.log.Error("taskUsecase.UpdateTaskStatus: %v", err)
+		t.log.Error("taskUsecase.GetTaskByID: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
 		return
 	}
+	// Без If-Match патч применяется к прочитанному состоянию, параллельное изменение все равно дает 412
+	if version == 0 {
+		version = task.Version
+	}
 
-	w.WriteHeader(http.StatusOK)
-	e := json.NewEncoder(w)
-	e.SetIndent(" ", " ")
-	e.Encode(updatedTask)
-}
-
-func getUserID(ctx context.Context) string {
-	userID, _ := ctx.Value("userID").(string)
-
-	return userID
-}
+	doc, err := json.Marshal(newTaskPatch(task))
+	if err != nil {
+		t.log.Error("json.Marshal: %v", err)
+		HandleError(w, err, http.StatusInternalServerError)
+		return
+	}
 
-func getRole(ctx context.Context) string {
+	if mediaType == jsonPatchType {
+		doc, err = jsonpatch.Apply(doc, body)
+	} else {
+		doc, err = jsonpatch.MergePatch(doc, body)
+	}
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		ErrorJSON(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, jsonpatch.ErrPathNotFound):
+		ErrorJSON(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	case err != nil:
+		t.log.Error("jsonpatch: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	data := new(TaskPatch)
+	d := json.NewDecoder(bytes.NewReader(doc))
+	d.DisallowUnknownFields()
+	if err := d.Decode(data); err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		HandleError(w, apperror.ErrDataNotValid, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := data.apply(task); err != nil {
+		t.log.Error("TaskPatch.apply: %v", err)
+		HandleError(w, apperror.ErrDataNotValid, http.StatusUnprocessableEntity)
+		return
+	}
+	task.Version = version
+
+	patchedTask, err := t.taskUsecase.PatchTask(r.Context(), task)
+	if errors.Is(err, apperror.ErrVersionMismatch) {
+		t.versionConflict(w, taskID)
+		return
+	}
+	if err != nil {
+		t.log.Error("taskUsecase.PatchTask: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	setETag(w, patchedTask)
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(patchedTask)
+}
+
+// GetAllTasksHandler godoc
+// @Summary Get all users task
+// @Tags Task
+// @Description Get all users tasks, available only to the admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} []entity.Task
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/all [get]
+func (t *taskHandler) GetAllTasksHandler(w http.ResponseWriter, r *http.Request) {
+	role := getRole(r.Context())
+	if role != "admin" {
+		AccessError(w)
+		return
+	}
+
+	tasks, err := t.taskUsecase.GetAllTasks(context.Background())
+	if err != nil {
+		t.log.Error("taskUsecase.GetAllTasks: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(tasks)
+}
+
+// UpdateStatusHandler godoc
+// @Summary Set status
+// @Tags Task
+// @Description Set to task completed or not by userID from context, with cascade the status is applied to all subtasks.
+// @Description Status can also be a board column name: the task is moved to the column if the transition is allowed,
+// @Description a done column completes the task. Completing a recurring task creates its next occurrence, return updated task.
+// @Description If-Match must hold the task ETag, on conflict 412 is returned with the current task and its ETag
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Param If-Match header string true "task ETag from a previous response, * skips the check"
+// @Param input body StatusRequest true "task attribute"
+// @Success 200 {object} entity.Task
+// @Header 200 {string} ETag "task version"
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 409 {object} JSONError
+// @Failure 412 {object} entity.Task
+// @Failure 422 {object} JSONError
+// @Failure 428 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/status [put]
+func (t *taskHandler) UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	taskID, err := strconv.Atoi(param)
+	if err != nil {
+		t.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		t.log.Error("parseIfMatch: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	data := new(StatusRequest)
+	d := json.NewDecoder(r.Body)
+	err = d.Decode(&data)
+	if err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := t.taskUsecase.IsEqualUserID(context.Background(), userID, taskID)
+	if err != nil {
+		t.log.Error("taskUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	var updatedTask *entity.Task
+	if data.Status.Done != nil {
+		updatedTask, err = t.taskUsecase.UpdateTaskStatus(r.Context(), *data.Status.Done, taskID, data.Cascade, version)
+	} else {
+		updatedTask, err = t.taskUsecase.MoveTaskToStatus(r.Context(), taskID, userID, data.Status.Name, version)
+	}
+	if errors.Is(err, apperror.ErrVersionMismatch) {
+		t.versionConflict(w, taskID)
+		return
+	}
+	if err != nil {
+		t.log.Error("taskUsecase.UpdateTaskStatus: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	setETag(w, updatedTask)
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(updatedTask)
+}
+
+// GetSubtasksHandler godoc
+// @Summary Get subtasks
+// @Tags Task
+// @Description Get direct subtasks of task, each with its own child count and progress
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Success 200 {object} []entity.Task
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/subtasks [get]
+func (t *taskHandler) GetSubtasksHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	taskID, err := strconv.Atoi(param)
+	if err != nil {
+		t.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := t.taskUsecase.IsEqualUserID(context.Background(), userID, taskID)
+	if err != nil {
+		t.log.Error("taskUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	tasks, err := t.taskUsecase.GetSubtasks(context.Background(), taskID)
+	if err != nil {
+		t.log.Error("taskUsecase.GetSubtasks: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(tasks)
+}
+
+// MoveTaskHandler godoc
+// @Summary Move task to project
+// @Tags Task
+// @Description Move task to another project of user from context or out of any project with null, return updated task
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Param input body MoveRequest true "target project"
+// @Success 200 {object} entity.Task
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/project [put]
+func (t *taskHandler) MoveTaskHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	taskID, err := strconv.Atoi(param)
+	if err != nil {
+		t.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	data := new(MoveRequest)
+	d := json.NewDecoder(r.Body)
+	err = d.Decode(&data)
+	if err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := t.taskUsecase.IsEqualUserID(context.Background(), userID, taskID)
+	if err != nil {
+		t.log.Error("taskUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	movedTask, err := t.taskUsecase.MoveTask(r.Context(), taskID, userID, data.ProjectID)
+	if err != nil {
+		t.log.Error("taskUsecase.MoveTask: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	setETag(w, movedTask)
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(movedTask)
+}
+
+// ReorderTaskHandler godoc
+// @Summary Reorder task
+// @Tags Task
+// @Description Place task in the manual order between neighbours: after the task with id after and before the task with id before,
+// @Description one neighbour is enough. Only the moved task gets a new position, return updated task
+// @Accept json
+// @Produce json
+// @Param id path int true "task id"
+// @Param input body ReorderRequest true "neighbour tasks"
+// @Success 200 {object} entity.Task
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/{id}/move [put]
+func (t *taskHandler) ReorderTaskHandler(w http.ResponseWriter, r *http.Request) {
+	param := chi.URLParam(r, "id")
+	taskID, err := strconv.Atoi(param)
+	if err != nil {
+		t.log.Error("strconv.Atoi: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	data := new(ReorderRequest)
+	d := json.NewDecoder(r.Body)
+	err = d.Decode(&data)
+	if err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	equal, err := t.taskUsecase.IsEqualUserID(context.Background(), userID, taskID)
+	if err != nil {
+		t.log.Error("taskUsecase.IsEqualUserID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	if !equal {
+		AccessError(w)
+		return
+	}
+
+	reorderedTask, err := t.taskUsecase.ReorderTask(r.Context(), taskID, userID, data.After, data.Before)
+	if err != nil {
+		t.log.Error("taskUsecase.ReorderTask: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	setETag(w, reorderedTask)
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(reorderedTask)
+}
+
+// BulkTaskHandler godoc
+// @Summary Bulk task operation
+// @Tags Task
+// @Description Complete, reopen, delete, tag, untag or move many tasks of the user from context in one transaction.
+// @Description Tasks are taken from ids or, when ids are empty, from the filter in query parameters, the same as for GET /tasks.
+// @Description Without ids at least one filter is required, otherwise 422
+// @Description At most 500 tasks per operation. Tasks of other users and missing tasks are skipped and reported per item
+// @Accept json
+// @Produce json
+// @Param input body BulkRequest true "operation"
+// @Param status query boolean false "task status" Format(status)
+// @Param priority query string false "task priority" Enums(none, low, medium, high, urgent)
+// @Param due query string false "due date filter" Enums(overdue, today, week)
+// @Param tag query []int false "tag id, can be repeated" collectionFormat(multi)
+// @Param project_id query int false "project id"
+// @Success 200 {object} entity.BulkReport
+// @Failure 400 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /tasks/bulk [post]
+func (t *taskHandler) BulkTaskHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(BulkRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	userID := getUserID(r.Context())
+
+	bulk := &entity.Bulk{
+		Action:    data.Action,
+		UserID:    userID,
+		IDs:       data.IDs,
+		TagIDs:    data.Tags,
+		ProjectID: data.ProjectID,
+	}
+
+	// Без ids задачи отбираются фильтром, пустой фильтр отклоняет usecase
+	if len(data.IDs) == 0 {
+		bulk.Query, err = parseTaskQuery(r.URL.Query())
+		if err != nil {
+			t.log.Error("parseTaskQuery: %v", err)
+			paramOptionError(w, err)
+			return
+		}
+		bulk.Query.UserID = userID
+	}
+
+	report, err := t.taskUsecase.BulkTasks(r.Context(), bulk)
+	if err != nil {
+		t.log.Error("taskUsecase.BulkTasks: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(report)
+}
+
+// setETag отдает версию задачи в заголовке ETag, клиент возвращает ее в If-Match при изменении
+func setETag(w http.ResponseWriter, task *entity.Task) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(task.Version)))
+}
+
+// parseIfMatch возвращает версию задачи из заголовка If-Match, * отключает проверку и дает 0.
+// Значение, не похожее на ETag задачи, дает -1, которая не совпадет ни с одной версией
+func parseIfMatch(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, apperror.ErrVersionRequired
+	}
+	if value == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(value, "W/"))
+	if err != nil {
+		return -1, nil
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return -1, nil
+	}
+	return version, nil
+}
+
+// versionConflict отвечает 412 с текущим состоянием задачи, чтобы клиент мог повторить изменение
+func (t *taskHandler) versionConflict(w http.ResponseWriter, taskID int) {
+	current, err := t.taskUsecase.GetTaskByID(context.Background(), taskID)
+	if err != nil {
+		t.log.Error("taskUsecase.GetTaskByID: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	setETag(w, current)
+	w.WriteHeader(http.StatusPreconditionFailed)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(current)
+}
+
+func getUserID(ctx context.Context) string {
+	userID, _ := ctx.Value("userID").(string)
+
+	return userID
+}
+
+func getRole(ctx context.Context) string {
 	role, _ := ctx.Value("role").(string)
 
 	return role
 }
 
+var errTimeNotValid = errors.New("the time was entered incorrectly")
+
+// parseTaskQuery разбирает параметры пагинации, сортировки и фильтрации списка задач
+func parseTaskQuery(query url.Values) (*entity.TaskQuery, error) {
+	opt := new(entity.TaskQuery)
+
+	pageString := query.Get("page")
+	if pageString != "" {
+		page, err := strconv.Atoi(pageString)
+		if err != nil {
+			return nil, err
+		}
+		if page < 0 {
+			return nil, fmt.Errorf("negative page: %d", page)
+		}
+		opt.Page = page
+	}
+
+	pageSizeString := query.Get("page_size")
+	if pageSizeString != "" {
+		pageSize, err := strconv.Atoi(pageSizeString)
+		if err != nil {
+			return nil, err
+		}
+		if pageSize < 1 || pageSize > entity.MaxPageSize {
+			return nil, fmt.Errorf("page size out of range: %d", pageSize)
+		}
+		opt.PageSize = pageSize
+	}
+
+	_, hasAfter := query["after"]
+	_, hasBefore := query["before"]
+	if hasAfter || hasBefore {
+		if hasAfter && hasBefore || opt.Page != 0 {
+			return nil, errors.New("only one of page, after and before can be used")
+		}
+		opt.CursorMode = true
+
+		var err error
+		if after := query.Get("after"); after != "" {
+			if opt.After, err = entity.DecodeCursor(after); err != nil {
+				return nil, err
+			}
+		}
+		if before := query.Get("before"); before != "" {
+			if opt.Before, err = entity.DecodeCursor(before); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// datetime оставлен для совместимости: задачи, начинающиеся ровно в указанную минуту, с любым статусом
+	datetime := query.Get("datetime")
+	if datetime != "" {
+		parsedDate, err := time.Parse("02.01.2006 15:04", datetime)
+		if err != nil {
+			return nil, errTimeNotValid
+		}
+		opt.Filter.StartRange = entity.TimeRange{From: &parsedDate, To: &parsedDate}
+	}
+
+	for _, r := range []struct {
+		name  string
+		value *entity.TimeRange
+	}{
+		{name: "start", value: &opt.Filter.StartRange},
+		{name: "created", value: &opt.Filter.CreatedRange},
+		{name: "due", value: &opt.Filter.DueRange},
+	} {
+		if err := parseTimeRange(query, r.name, r.value); err != nil {
+			return nil, err
+		}
+	}
+
+	statusString := query.Get("status")
+	if statusString != "" {
+		status, err := strconv.ParseBool(statusString)
+		if err != nil {
+			return nil, err
+		}
+		opt.Filter.Status = &status
+	}
+
+	priorityString := query.Get("priority")
+	if priorityString != "" {
+		priority := entity.Priority(priorityString)
+		if !entity.IsPriorityValid(priority) {
+			return nil, fmt.Errorf("unknown priority: %s", priorityString)
+		}
+		opt.Filter.Priority = &priority
+	}
+
+	sortString := query.Get("sort")
+	if sortString != "" {
+		sortBy := entity.SortField(sortString)
+		if !entity.IsSortFieldValid(sortBy) {
+			return nil, fmt.Errorf("unknown sort field: %s", sortString)
+		}
+		opt.Sort.Field = sortBy
+	}
+
+	orderString := query.Get("order")
+	if orderString != "" {
+		order := entity.SortOrder(orderString)
+		if !entity.IsSortOrderValid(order) {
+			return nil, fmt.Errorf("unknown sort order: %s", orderString)
+		}
+		opt.Sort.Order = order
+	}
+
+	opt.Filter.Text = query.Get("q")
+
+	dueString := query.Get("due")
+	if dueString != "" {
+		due := entity.DueFilter(dueString)
+		if !entity.IsDueFilterValid(due) {
+			return nil, fmt.Errorf("unknown due filter: %s", dueString)
+		}
+		opt.Filter.Due = due
+	}
+
+	tags, err := parseTagIDs(query["tag"])
+	if err != nil {
+		return nil, err
+	}
+	opt.Filter.Tags = tags
+
+	tagModeString := query.Get("tag_mode")
+	if tagModeString != "" {
+		tagMode := entity.TagMode(tagModeString)
+		if !entity.IsTagModeValid(tagMode) {
+			return nil, fmt.Errorf("unknown tag mode: %s", tagModeString)
+		}
+		opt.Filter.TagMode = tagMode
+	}
+
+	projectString := query.Get("project_id")
+	if projectString != "" {
+		projectID, err := strconv.Atoi(projectString)
+		if err != nil {
+			return nil, err
+		}
+		opt.Filter.ProjectID = &projectID
+	}
+
+	return opt, nil
+}
+
+func paramOptionError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errTimeNotValid) {
+		ParseTimeError(w)
+		return
+	}
+	QueryError(w)
+}
+
+// parseTagIDs разбирает повторяющийся параметр tag, дубликаты отбрасываются
+func parseTagIDs(values []string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]struct{}, len(values))
+	for _, value := range values {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+var (
+	dateLayouts     = []string{"02.01.2006", "2006-01-02"}
+	dateTimeLayouts = []string{"02.01.2006 15:04", "2006-01-02T15:04", "2006-01-02T15:04:05"}
+	// zonedLayouts содержат смещение клиента, время переводится в пояс сервера
+	zonedLayouts = []string{time.RFC3339}
+)
+
+// parseTimeRange разбирает границы name_from и name_to, дата без времени в name_to включает весь день.
+// Указанные границы заменяют границы из r
+func parseTimeRange(query url.Values, name string, r *entity.TimeRange) error {
+	from, _, err := parseDueDate(query.Get(name + "_from"))
+	if err != nil {
+		return errTimeNotValid
+	}
+	to, hasTime, err := parseDueDate(query.Get(name + "_to"))
+	if err != nil {
+		return errTimeNotValid
+	}
+	if to != nil && !hasTime {
+		endOfDay := to.AddDate(0, 0, 1).Add(-time.Microsecond)
+		to = &endOfDay
+	}
+
+	if from != nil {
+		r.From = from
+	}
+	if to != nil {
+		r.To = to
+	}
+	if !r.IsValid() {
+		return errTimeNotValid
+	}
+	return nil
+}
+
+// parseDueDate разбирает срок задачи, hasTime сообщает, было ли указано время
+func parseDueDate(value string) (due *time.Time, hasTime bool, err error) {
+	if value == "" {
+		return nil, false, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, false, nil
+		}
+	}
+	for _, layout := range dateTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, true, nil
+		}
+	}
+	// Сроки хранятся настенным временем сервера, поэтому смещение учитывается до отбрасывания пояса
+	for _, layout := range zonedLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			parsed = entity.WallClock(parsed.In(time.Local))
+			return &parsed, true, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("unsupported date format: %s", value)
+}
+
 func (t *taskHandler) GetUserTaskHandler(w http.ResponseWriter, r *http.Request) {
-	opt := new(entity.ParamOption)
+	opt := new(entity.TaskQuery)
 
 	datetime := r.URL.Query().Get("datetime")
 	if datetime != "" {
@@ -366,7 +1462,7 @@ func (t *taskHandler) GetUserTaskHandler(w http.ResponseWriter, r *http.Request)
 			ParseTimeError(w)
 			return
 		}
-		opt.DateTime = parsedDate
+		opt.Filter.StartRange = entity.TimeRange{From: &parsedDate, To: &parsedDate}
 	}
 
 	statusString := r.URL.Query().Get("status")
@@ -377,7 +1473,7 @@ func (t *taskHandler) GetUserTaskHandler(w http.ResponseWriter, r *http.Request)
 			QueryError(w)
 			return
 		}
-		opt.Status = &status
+		opt.Filter.Status = &status
 	}
 
 	if datetime == "" || statusString == "" {
@@ -386,9 +1482,9 @@ func (t *taskHandler) GetUserTaskHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	userID := getUserID(r.Context())
+	opt.UserID = getUserID(r.Context())
 
-	tasks, err := t.taskUsecase.GetUserTasks(context.Background(), userID, opt)
+	tasks, err := t.taskUsecase.GetUserTasks(context.Background(), opt)
 	if err != nil {
 		t.log.Error("taskUsecase.GetUserTasks: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
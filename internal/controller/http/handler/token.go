@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/token"
+	"go-todolist-sber/pkg/logger"
+	"net/http"
+	"time"
+)
+
+type tokenHandler struct {
+	tokenUsecase token.TokenUsecase
+	log          *logger.Logger
+}
+
+func NewTokenHandler(tokenUsecase token.TokenUsecase, log *logger.Logger) *tokenHandler {
+	return &tokenHandler{
+		tokenUsecase: tokenUsecase,
+		log:          log,
+	}
+}
+
+type TokenRequest struct {
+	Name      string            `json:"name"`
+	Scope     entity.TokenScope `json:"scope"`
+	ExpiresAt *time.Time        `json:"expires_at"`
+}
+
+// GetTokensHandler godoc
+// @Summary Get personal access tokens
+// @Tags Auth
+// @Description list personal access tokens of the user from context, the secret itself is never returned. Not available with personal access token auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} []entity.AccessToken
+// @Failure 403 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /user/tokens [get]
+func (t *tokenHandler) GetTokensHandler(w http.ResponseWriter, r *http.Request) {
+	tokens, err := t.tokenUsecase.GetTokens(context.Background(), getUserID(r.Context()))
+	if err != nil {
+		t.log.Error("tokenUsecase.GetTokens: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(tokens)
+}
+
+// CreateTokenHandler godoc
+// @Summary Create personal access token
+// @Tags Auth
+// @Description create named token for scripts and CI with read or write scope and optional expiry, the token is shown only in this response. Not available with personal access token auth
+// @Accept json
+// @Produce json
+// @Param input body TokenRequest true "token attributes"
+// @Success 201 {object} entity.NewAccessToken
+// @Failure 400 {object} JSONError
+// @Failure 403 {object} JSONError
+// @Failure 422 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /user/tokens [post]
+func (t *tokenHandler) CreateTokenHandler(w http.ResponseWriter, r *http.Request) {
+	data := new(TokenRequest)
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(&data)
+	if err != nil {
+		t.log.Error("json.NewDecoder: %v", err)
+		DecodingError(w)
+		return
+	}
+
+	createdToken, err := t.tokenUsecase.CreateToken(context.Background(), &entity.AccessToken{
+		UserID:    getUserID(r.Context()),
+		Name:      data.Name,
+		Scope:     data.Scope,
+		ExpiresAt: data.ExpiresAt,
+	})
+	if err != nil {
+		t.log.Error("tokenUsecase.CreateToken: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(createdToken)
+}
+
+// DeleteTokenHandler godoc
+// @Summary Revoke personal access token
+// @Tags Auth
+// @Description revoke token with id, requests with the token are rejected right away. Not available with personal access token auth
+// @Accept json
+// @Produce json
+// @Param id path string true "token id"
+// @Success 204
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /user/tokens/{id} [delete]
+func (t *tokenHandler) DeleteTokenHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		HandleError(w, apperror.ErrNoRows, http.StatusNotFound)
+		return
+	}
+
+	err := t.tokenUsecase.DeleteToken(context.Background(), id, getUserID(r.Context()))
+	if err != nil {
+		t.log.Error("tokenUsecase.DeleteToken: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,12 +3,15 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/gorilla/sessions"
 	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
 	"go-todolist-sber/internal/session"
 	"go-todolist-sber/internal/user"
 	"go-todolist-sber/pkg/logger"
+	"net"
 	"net/http"
 )
 
@@ -33,6 +36,12 @@ type UserRequest struct {
 	Password string `json:"password"`
 }
 
+type SessionsDeleted struct {
+	Deleted int64 `json:"deleted"`
+}
+
+const maxUserAgentLen = 512
+
 // RegisterHandler godoc
 // @Summary Register new user
 // @Tags Auth
@@ -62,7 +71,7 @@ func (u *userHandler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sess, err := u.sessionUsecase.CreateToken(context.Background(), uuid.New().String(), user.ID)
+	sess, err := u.sessionUsecase.CreateToken(context.Background(), uuid.New().String(), user.ID, deviceFromRequest(r), u.cookieToken(r))
 	if err != nil {
 		u.log.Error("sessionUsecase.CreateToken: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
@@ -80,7 +89,8 @@ func (u *userHandler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
 // LoginHandler godoc
 // @Summary Login user
 // @Tags Auth
-// @Description login user,returns user and set session
+// @Description login user,returns user and set session. Every login opens a new session and revokes the session of the
+// @Description request cookie, sessions on other devices stay active
 // @Accept json
 // @Produce json
 // @Param input body UserRequest true "user login and password"
@@ -107,9 +117,9 @@ func (u *userHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sess, err := u.sessionUsecase.UpdateToken(context.Background(), uuid.New().String(), user.ID)
+	sess, err := u.sessionUsecase.CreateToken(context.Background(), uuid.New().String(), user.ID, deviceFromRequest(r), u.cookieToken(r))
 	if err != nil {
-		u.log.Error("sessionUsecase.UpdateToken: %v", err)
+		u.log.Error("sessionUsecase.CreateToken: %v", err)
 		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
 		return
 	}
@@ -125,16 +135,132 @@ func (u *userHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 // LogoutHandler godoc
 // @Summary Logout user
 // @Tags Auth
-// @Description logout user removing session
+// @Description logout user revoking the session on the server, the session cookie is no longer accepted even if it was copied
 // @Accept json
 // @Produce json
 // @Success 200
+// @Failure 500 {object} JSONError
 // @Router /user/logout [post]
 func (u *userHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	if err := u.sessionUsecase.DeleteToken(context.Background(), u.cookieToken(r)); err != nil {
+		u.log.Error("sessionUsecase.DeleteToken: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
 	u.authenticated(w, r, "", false)
 	w.WriteHeader(http.StatusOK)
 }
 
+// GetSessionsHandler godoc
+// @Summary Get user sessions
+// @Tags Auth
+// @Description list sessions of the user from context on all devices, the session of the request is marked as current
+// @Accept json
+// @Produce json
+// @Success 200 {object} []entity.Session
+// @Failure 403 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /user/sessions [get]
+func (u *userHandler) GetSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	sessions, err := u.sessionUsecase.GetSessions(context.Background(), getUserID(r.Context()), getToken(r.Context()))
+	if err != nil {
+		u.log.Error("sessionUsecase.GetSessions: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(sessions)
+}
+
+// DeleteSessionHandler godoc
+// @Summary Delete user session
+// @Tags Auth
+// @Description log out the device of the session with id, the session of the request can be deleted as well
+// @Accept json
+// @Produce json
+// @Param id path string true "session id"
+// @Success 204
+// @Failure 403 {object} JSONError
+// @Failure 404 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /user/sessions/{id} [delete]
+func (u *userHandler) DeleteSessionHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		HandleError(w, apperror.ErrNoRows, http.StatusNotFound)
+		return
+	}
+
+	err := u.sessionUsecase.DeleteSession(context.Background(), id, getUserID(r.Context()))
+	if err != nil {
+		u.log.Error("sessionUsecase.DeleteSession: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteOtherSessionsHandler godoc
+// @Summary Log out everywhere else
+// @Tags Auth
+// @Description delete all sessions of the user from context except the session of the request
+// @Accept json
+// @Produce json
+// @Success 200 {object} SessionsDeleted
+// @Failure 403 {object} JSONError
+// @Failure 500 {object} JSONError
+// @Router /user/sessions [delete]
+func (u *userHandler) DeleteOtherSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	count, err := u.sessionUsecase.DeleteOtherSessions(context.Background(), getUserID(r.Context()), getToken(r.Context()))
+	if err != nil {
+		u.log.Error("sessionUsecase.DeleteOtherSessions: %v", err)
+		HandleError(w, err, apperror.ParseHTTPErrStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	e := json.NewEncoder(w)
+	e.SetIndent(" ", " ")
+	e.Encode(SessionsDeleted{Deleted: count})
+}
+
+// deviceFromRequest описывает устройство клиента. Адрес учитывает X-Forwarded-For и X-Real-IP только
+// от доверенных прокси, см. RealIPMiddleware
+func deviceFromRequest(r *http.Request) entity.Device {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	userAgent := r.UserAgent()
+	if runes := []rune(userAgent); len(runes) > maxUserAgentLen {
+		userAgent = string(runes[:maxUserAgentLen])
+	}
+	return entity.Device{UserAgent: userAgent, IP: ip}
+}
+
+func getToken(ctx context.Context) string {
+	token, _ := ctx.Value("token").(string)
+
+	return token
+}
+
+// cookieToken возвращает токен сессии из cookie запроса, пустая строка — cookie нет
+func (u *userHandler) cookieToken(r *http.Request) string {
+	session, err := u.store.Get(r, "session.id")
+	if err != nil {
+		u.log.Error("store.Get(r,sessionID): %v", err)
+	}
+
+	token, _ := session.Values["sessionID"].(string)
+	return token
+}
+
 func (u *userHandler) authenticated(w http.ResponseWriter, r *http.Request, sessionID string, authenticated bool) {
 	session, err := u.store.Get(r, "session.id")
 	if err != nil {
@@ -3,41 +3,125 @@ package http
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/sessions"
+	"go-todolist-sber/internal/audit"
 	"go-todolist-sber/internal/controller/http/handler"
+	"go-todolist-sber/internal/idempotency"
+	"go-todolist-sber/internal/jwtauth"
+	"go-todolist-sber/internal/project"
+	"go-todolist-sber/internal/reminder"
 	"go-todolist-sber/internal/session"
+	"go-todolist-sber/internal/status"
+	"go-todolist-sber/internal/tag"
 	"go-todolist-sber/internal/task"
+	"go-todolist-sber/internal/token"
 	"go-todolist-sber/internal/user"
 	"go-todolist-sber/pkg/logger"
 )
 
 type Services struct {
-	Task    task.TaskUsecase
-	User    user.UserUsecase
-	Session session.SessionUsecase
+	Task        task.TaskUsecase
+	Tag         tag.TagUsecase
+	Project     project.ProjectUsecase
+	Reminder    reminder.ReminderUsecase
+	Status      status.StatusUsecase
+	Audit       audit.AuditUsecase
+	Idempotency idempotency.IdempotencyUsecase
+	User        user.UserUsecase
+	Session     session.SessionUsecase
+	Token       token.TokenUsecase
+	// JWT задан только в режиме AUTH_MODE=jwt, тогда он заменяет вход по cookie сессии
+	JWT jwtauth.JWTUsecase
 }
 
 func Router(log *logger.Logger, service Services, store *sessions.CookieStore) *chi.Mux {
 	mux := chi.NewMux()
 
 	task := handler.NewTaskHandler(service.Task, log)
+	tag := handler.NewTagHandler(service.Tag, log)
+	project := handler.NewProjectHandler(service.Project, service.Task, log)
+	reminder := handler.NewReminderHandler(service.Reminder, service.Task, log)
+	status := handler.NewStatusHandler(service.Status, service.Task, log)
+	audit := handler.NewAuditHandler(service.Audit, log)
 	user := handler.NewUserHandler(service.User, service.Session, store, log)
+	token := handler.NewTokenHandler(service.Token, log)
 
-	auth := handler.AuthMiddleware(service.Session, store)
+	auth := handler.AuthMiddleware(service.Session, service.Token, store)
+	if service.JWT != nil {
+		auth = handler.JWTAuthMiddleware(service.JWT, service.Token)
+	}
+	idempotent := handler.IdempotencyMiddleware(service.Idempotency, log)
+	interactive := handler.ForbidAccessTokenMiddleware()
 
 	mux.Route("/", func(r chi.Router) {
+		if service.JWT != nil {
+			jwt := handler.NewJWTHandler(service.User, service.JWT, log)
+
+			r.Get("/.well-known/jwks.json", jwt.JWKSHandler)
+			r.Route("/auth", func(r chi.Router) {
+				r.Post("/register", jwt.RegisterHandler)
+				r.Post("/login", jwt.LoginHandler)
+				r.Post("/refresh", jwt.RefreshHandler)
+				r.Post("/logout", jwt.LogoutHandler)
+			})
+		}
 		r.Route("/user", func(r chi.Router) {
-			r.Post("/register", user.RegisterHandler)
-			r.Post("/login", user.LoginHandler)
-			r.Post("/logout", user.LogoutHandler)
+			if service.JWT == nil {
+				r.Post("/register", user.RegisterHandler)
+				r.Post("/login", user.LoginHandler)
+				r.Post("/logout", user.LogoutHandler)
+				r.With(auth, interactive).Get("/sessions", user.GetSessionsHandler)
+				r.With(auth, interactive).Delete("/sessions", user.DeleteOtherSessionsHandler)
+				r.With(auth, interactive).Delete("/sessions/{id}", user.DeleteSessionHandler)
+			}
+			r.With(auth, interactive).Get("/tokens", token.GetTokensHandler)
+			r.With(auth, interactive).Post("/tokens", token.CreateTokenHandler)
+			r.With(auth, interactive).Delete("/tokens/{id}", token.DeleteTokenHandler)
 		})
 		r.With(auth).Route("/tasks", func(r chi.Router) {
 			r.Get("/", task.GetTaskHandler)
-			r.Post("/add", task.CreateTaskHandler)
+			r.Get("/search", task.SearchTaskHandler)
+			r.Get("/board", task.GetBoardHandler)
+			r.Get("/trash", task.GetTrashHandler)
+			r.With(idempotent).Post("/add", task.CreateTaskHandler)
+			r.Post("/bulk", task.BulkTaskHandler)
 			r.Delete("/{id}", task.DeleteTaskHandler)
 			r.Put("/{id}", task.UpdateTaskHandler)
+			r.Patch("/{id}", task.PatchTaskHandler)
 			r.Put("/{id}/status", task.UpdateStatusHandler)
+			r.Put("/{id}/project", task.MoveTaskHandler)
+			r.Put("/{id}/move", task.ReorderTaskHandler)
+			r.Post("/{id}/restore", task.RestoreTaskHandler)
+			r.Get("/{id}/subtasks", task.GetSubtasksHandler)
+			r.Get("/{id}/history", audit.GetTaskHistoryHandler)
+			r.Get("/{id}/reminders", reminder.GetRemindersHandler)
+			r.Post("/{id}/reminders", reminder.CreateReminderHandler)
 			r.Get("/all", task.GetAllTasksHandler)
 		})
+		r.With(auth).Route("/tags", func(r chi.Router) {
+			r.Get("/", tag.GetTagsHandler)
+			r.Post("/add", tag.CreateTagHandler)
+			r.Put("/{id}", tag.UpdateTagHandler)
+			r.Delete("/{id}", tag.DeleteTagHandler)
+		})
+		r.With(auth).Route("/projects", func(r chi.Router) {
+			r.Get("/", project.GetProjectsHandler)
+			r.Post("/add", project.CreateProjectHandler)
+			r.Put("/{id}", project.RenameProjectHandler)
+			r.Put("/{id}/archive", project.ArchiveProjectHandler)
+			r.Delete("/{id}", project.DeleteProjectHandler)
+			r.Get("/{id}/tasks", project.GetProjectTasksHandler)
+			r.Get("/{id}/board", project.GetProjectBoardHandler)
+		})
+		r.With(auth).Route("/statuses", func(r chi.Router) {
+			r.Get("/", status.GetStatusesHandler)
+			r.Post("/add", status.CreateStatusHandler)
+			r.Put("/{id}", status.UpdateStatusHandler)
+			r.Delete("/{id}", status.DeleteStatusHandler)
+		})
+		r.With(auth).Route("/reminders", func(r chi.Router) {
+			r.Delete("/{id}", reminder.DeleteReminderHandler)
+		})
+		r.With(auth).Get("/audit", audit.GetAuditLogHandler)
 	})
 
 	return mux
@@ -10,10 +10,13 @@ import (
 	"go-todolist-sber/internal/controller/http/handler"
 	"go-todolist-sber/pkg/logger"
 	"net/http"
+	"net/netip"
 )
 
 type ServerOption struct {
 	Addr string
+	// TrustedProxies — сети прокси, которым доверяются X-Forwarded-For и X-Real-IP
+	TrustedProxies []netip.Prefix
 }
 
 func NewServer(log *logger.Logger, services Services, opts ServerOption, store *sessions.CookieStore) *http.Server {
@@ -22,12 +25,14 @@ func NewServer(log *logger.Logger, services Services, opts ServerOption, store *
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{http.MethodHead, http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"ETag", "Idempotent-Replayed"},
 		AllowCredentials: true,
 	}))
 
-	mux.Use(middleware.RealIP,
+	mux.Use(middleware.RequestID,
+		handler.RealIPMiddleware(opts.TrustedProxies),
 		middleware.Recoverer,
-		middleware.AllowContentType("application/json"),
+		middleware.AllowContentType("application/json", "application/merge-patch+json", "application/json-patch+json"),
 		handler.MiddlewareLogger(log),
 	)
 
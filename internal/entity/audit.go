@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditStatus AuditAction = "status"
+	AuditDelete AuditAction = "delete"
+	// AuditRestore — возврат задачи из корзины, AuditPurge — удаление без возможности восстановления
+	AuditRestore AuditAction = "restore"
+	AuditPurge   AuditAction = "purge"
+)
+
+// AuditEntry — запись журнала изменений задачи: кто, когда и в рамках какого запроса ее изменил.
+// Записи хранятся и после окончательного удаления задачи
+type AuditEntry struct {
+	ID     int64       `json:"id"`
+	TaskID int         `json:"task_id"`
+	Action AuditAction `json:"action"`
+	// UserID — владелец задачи, ActorID — пользователь, выполнивший изменение
+	UserID  string `json:"user_id"`
+	ActorID string `json:"actor_id"`
+	// Before и After — состояние задачи до и после изменения, nil если задачи не было или она недоступна
+	Before    *Task     `json:"before"`
+	After     *Task     `json:"after"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditQuery описывает выборку из журнала, все заданные условия должны выполняться одновременно
+type AuditQuery struct {
+	TaskID  *int
+	UserID  string
+	ActorID string
+	Created TimeRange
+
+	Page     int
+	PageSize int
+}
+
+type AuditPage struct {
+	Items []AuditEntry `json:"items"`
+	PageInfo
+}
+
+func NewAuditPage(items []AuditEntry, total, page, pageSize int) *AuditPage {
+	if items == nil {
+		items = []AuditEntry{}
+	}
+	return &AuditPage{Items: items, PageInfo: NewPageInfo(total, page, pageSize)}
+}
+
+// Actor — автор изменения: пользователь и id HTTP-запроса, в рамках которого оно сделано
+type Actor struct {
+	UserID    string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor сохраняет автора изменений в ctx, чтобы журнал мог его записать
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает автора изменений из ctx, пустого для фоновых операций
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
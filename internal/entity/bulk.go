@@ -0,0 +1,76 @@
+package entity
+
+import "time"
+
+type BulkAction string
+
+const (
+	BulkComplete BulkAction = "complete"
+	BulkReopen   BulkAction = "reopen"
+	// BulkDelete переносит задачи в корзину вместе с подзадачами
+	BulkDelete BulkAction = "delete"
+	// BulkAddTags и BulkRemoveTags добавляют и снимают метки, остальные метки задач не меняются
+	BulkAddTags    BulkAction = "add_tags"
+	BulkRemoveTags BulkAction = "remove_tags"
+	// BulkMove переносит задачи в проект, пустой проект выносит их из проекта
+	BulkMove BulkAction = "move"
+)
+
+func IsBulkActionValid(action BulkAction) bool {
+	switch action {
+	case BulkComplete, BulkReopen, BulkDelete, BulkAddTags, BulkRemoveTags, BulkMove:
+		return true
+	}
+	return false
+}
+
+// Bulk — групповая операция над задачами пользователя. Задачи задаются списком IDs,
+// а если он пуст — фильтром Query
+type Bulk struct {
+	Action BulkAction
+	UserID string
+	IDs    []int
+	Query  *TaskQuery
+	// TagIDs используются для BulkAddTags и BulkRemoveTags, ProjectID — для BulkMove
+	TagIDs    []int
+	ProjectID *int
+	// At — время операции, становится отметкой удаления для BulkDelete
+	At time.Time
+}
+
+type BulkStatus string
+
+const (
+	BulkOK BulkStatus = "ok"
+	// BulkNotFound — задачи нет или она в корзине, BulkForbidden — задача принадлежит другому пользователю
+	BulkNotFound  BulkStatus = "not_found"
+	BulkForbidden BulkStatus = "forbidden"
+)
+
+// BulkResult — результат групповой операции для одной задачи. Task — состояние задачи после операции,
+// для удаленной задачи отсутствует, Before — состояние до операции для журнала изменений
+type BulkResult struct {
+	ID     int        `json:"id"`
+	Status BulkStatus `json:"status"`
+	Task   *Task      `json:"task,omitempty"`
+	Before *Task      `json:"-"`
+}
+
+type BulkReport struct {
+	Action    BulkAction   `json:"action"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []BulkResult `json:"results"`
+}
+
+func NewBulkReport(action BulkAction, results []BulkResult) *BulkReport {
+	report := &BulkReport{Action: action, Results: results}
+	for _, result := range results {
+		if result.Status == BulkOK {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	return report
+}
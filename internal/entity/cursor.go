@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const cursorTimeLayout = "2006-01-02T15:04:05.999999"
+
+var ErrCursorNotValid = errors.New("cursor not valid")
+
+// Cursor — позиция в списке задач для курсорной пагинации: значение ключа сортировки и id задачи.
+// Для клиента курсор непрозрачен и действителен только для той же сортировки, с которой был получен
+type Cursor struct {
+	SortBy SortField `json:"s"`
+	Order  SortOrder `json:"o"`
+	// Key — значение ключа сортировки, nil для сортировки по id и для задач без срока при сортировке по due_date
+	Key *string `json:"k,omitempty"`
+	ID  int     `json:"i"`
+}
+
+// NewCursor создает курсор, указывающий на task при сортировке sort
+func NewCursor(task *Task, sort TaskSort) *Cursor {
+	sort = sort.Normalize()
+	cursor := &Cursor{SortBy: sort.Field, Order: sort.Order, ID: task.ID}
+
+	var key string
+	switch sort.Field {
+	case SortByPriority:
+		key = string(task.Priority)
+	case SortByStartDate:
+		key = task.StartDate.Format(cursorTimeLayout)
+	case SortByCreatedAt:
+		key = task.CreatedAt.Format(cursorTimeLayout)
+	case SortByManual:
+		key = task.Position
+	case SortByDueDate:
+		if task.DueDate == nil {
+			return cursor
+		}
+		key = task.DueDate.Format(cursorTimeLayout)
+	default:
+		return cursor
+	}
+	cursor.Key = &key
+
+	return cursor
+}
+
+// Matches сообщает, получен ли курсор при той же сортировке sort
+func (c *Cursor) Matches(sort TaskSort) bool {
+	sort = sort.Normalize()
+	return c.SortBy == sort.Field && c.Order == sort.Order
+}
+
+// KeyValue возвращает значение ключа сортировки в типе столбца: строку для приоритета и позиции и time.Time для дат
+func (c *Cursor) KeyValue() interface{} {
+	if c.Key == nil {
+		return nil
+	}
+
+	switch c.SortBy {
+	case SortByStartDate, SortByCreatedAt, SortByDueDate:
+		value, _ := time.Parse(cursorTimeLayout, *c.Key)
+		return value
+	}
+	return *c.Key
+}
+
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrCursorNotValid
+	}
+
+	cursor := new(Cursor)
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, ErrCursorNotValid
+	}
+	if !IsSortFieldValid(cursor.SortBy) || !IsSortOrderValid(cursor.Order) || cursor.ID <= 0 {
+		return nil, ErrCursorNotValid
+	}
+	if !cursor.isKeyValid() {
+		return nil, ErrCursorNotValid
+	}
+
+	return cursor, nil
+}
+
+func (c *Cursor) isKeyValid() bool {
+	switch c.SortBy {
+	case SortByPriority:
+		return c.Key != nil && IsPriorityValid(Priority(*c.Key))
+	case SortByManual:
+		return c.Key != nil
+	case SortByStartDate, SortByCreatedAt:
+		return c.Key != nil && isCursorTime(*c.Key)
+	case SortByDueDate:
+		return c.Key == nil || isCursorTime(*c.Key)
+	}
+	return c.Key == nil
+}
+
+func isCursorTime(value string) bool {
+	_, err := time.Parse(cursorTimeLayout, value)
+	return err == nil
+}
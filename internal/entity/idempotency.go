@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyKey — ответ на запрос с заголовком Idempotency-Key. Повтор запроса с тем же ключом
+// получает сохраненный ответ вместо повторного выполнения
+type IdempotencyKey struct {
+	UserID string
+	Key    string
+	// RequestHash — хеш метода, пути и тела запроса, повтор ключа с другим запросом отклоняется
+	RequestHash string
+	// StatusCode равен 0, пока первый запрос с ключом еще выполняется
+	StatusCode int
+	Header     http.Header
+	Response   []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+// Completed сообщает, сохранен ли уже ответ на запрос
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
@@ -0,0 +1,61 @@
+package entity
+
+// PageInfo описывает страницу списка: общее количество элементов и номера соседних страниц
+type PageInfo struct {
+	Total    int  `json:"total"`
+	Page     int  `json:"page"`
+	PageSize int  `json:"page_size"`
+	NextPage *int `json:"next_page"`
+	PrevPage *int `json:"prev_page"`
+	// NextCursor и PrevCursor заполняются в режиме курсорной пагинации вместо номеров страниц
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// NewPageInfo описывает страницу page размера pageSize. Для page = 0 список возвращается целиком без пагинации
+func NewPageInfo(total, page, pageSize int) PageInfo {
+	info := PageInfo{
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	if page == 0 {
+		info.PageSize = 0
+		return info
+	}
+
+	if page*pageSize < total {
+		next := page + 1
+		info.NextPage = &next
+	}
+	if page > 1 {
+		prev := page - 1
+		info.PrevPage = &prev
+	}
+
+	return info
+}
+
+type TaskPage struct {
+	Items []Task `json:"items"`
+	PageInfo
+}
+
+func NewTaskPage(items []Task, total, page, pageSize int) *TaskPage {
+	if items == nil {
+		items = []Task{}
+	}
+	return &TaskPage{Items: items, PageInfo: NewPageInfo(total, page, pageSize)}
+}
+
+type SearchPage struct {
+	Items []SearchResult `json:"items"`
+	PageInfo
+}
+
+func NewSearchPage(items []SearchResult, total, page, pageSize int) *SearchPage {
+	if items == nil {
+		items = []SearchResult{}
+	}
+	return &SearchPage{Items: items, PageInfo: NewPageInfo(total, page, pageSize)}
+}
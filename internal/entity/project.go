@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type Project struct {
+	ID        int       `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Archived  bool      `json:"archived"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func IsProjectNameValid(name string) bool {
+	return name != "" && len(name) <= 100
+}
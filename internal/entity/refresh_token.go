@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// RefreshToken — одноразовый токен обновления в режиме JWT. Каждое обновление выдает новый токен
+// той же семьи FamilyID, а старый помечается UsedAt, чтобы заметить его повторное предъявление.
+// FamilyCreatedAt — время входа, от него отсчитывается предельное время жизни семьи
+type RefreshToken struct {
+	ID              string
+	FamilyID        string
+	FamilyCreatedAt time.Time
+	UserID          string
+	Hash            string
+	UserAgent       string
+	IP              string
+	ExpiresAt       time.Time
+	UsedAt          *time.Time
+	CreatedAt       time.Time
+
+	Role string
+}
+
+// TokenPair выдается при входе и обновлении в режиме JWT
+type TokenPair struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn — время жизни access token в секундах
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
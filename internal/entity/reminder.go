@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// ReminderAnchor — дата задачи, от которой отсчитывается напоминание
+type ReminderAnchor string
+
+const (
+	AnchorStart ReminderAnchor = "start"
+	AnchorDue   ReminderAnchor = "due"
+)
+
+// MaxReminderOffset ограничивает смещение напоминания одной неделей
+const MaxReminderOffset = 7 * 24 * 60
+
+type Reminder struct {
+	ID     int    `json:"id"`
+	TaskID int    `json:"task_id"`
+	UserID string `json:"user_id"`
+	// RemindAt — абсолютное время напоминания, задается вместо Anchor
+	RemindAt *time.Time     `json:"remind_at"`
+	Anchor   ReminderAnchor `json:"anchor"`
+	// Offset — за сколько минут до даты Anchor сработает напоминание
+	Offset    int        `json:"offset"`
+	Attempts  int        `json:"attempts"`
+	SentAt    *time.Time `json:"sent_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsReminderValid проверяет, что напоминание задано либо абсолютным временем, либо смещением от даты задачи
+func IsReminderValid(reminder *Reminder) bool {
+	if reminder.RemindAt != nil {
+		return reminder.Anchor == "" && reminder.Offset == 0
+	}
+
+	switch reminder.Anchor {
+	case AnchorStart, AnchorDue:
+		return reminder.Offset >= 0 && reminder.Offset <= MaxReminderOffset
+	}
+	return false
+}
+
+// Notification — сработавшее напоминание вместе с данными задачи для отправки пользователю
+type Notification struct {
+	ReminderID int        `json:"reminder_id"`
+	TaskID     int        `json:"task_id"`
+	UserID     string     `json:"user_id"`
+	Login      string     `json:"login"`
+	Header     string     `json:"header"`
+	StartDate  time.Time  `json:"start_date"`
+	DueDate    *time.Time `json:"due_date"`
+	FireAt     time.Time  `json:"fire_at"`
+}
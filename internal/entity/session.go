@@ -2,10 +2,26 @@ package entity
 
 import "time"
 
+// Session — вход пользователя с одного устройства. Token известен только клиенту этого устройства,
+// для управления сессиями используется ID
 type Session struct {
-	Token     string    `json:"token"`
+	ID        string    `json:"id"`
+	Token     string    `json:"-"`
 	UserID    string    `json:"user_id"`
 	ExpiresAt time.Time `json:"expires_at"`
 
-	Role string `json:"role"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Current отмечает сессию, с которой пришел запрос
+	Current bool `json:"current"`
+
+	Role string `json:"-"`
+}
+
+// Device описывает устройство, с которого выполнен вход
+type Device struct {
+	UserAgent string
+	IP        string
 }
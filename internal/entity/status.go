@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Status — колонка доски задач. Статусы задаются для пользователя или для отдельного проекта,
+// проект без своих статусов использует статусы пользователя
+type Status struct {
+	ID        int    `json:"id"`
+	UserID    string `json:"user_id"`
+	ProjectID *int   `json:"project_id"`
+	Name      string `json:"name"`
+	// Position задает порядок колонок. Без позиции новая колонка добавляется в конец,
+	// а при изменении порядок остается прежним
+	Position *int `json:"position"`
+	// Done отмечает завершающую колонку: задача в ней считается выполненной
+	Done bool `json:"done"`
+	// Transitions — id статусов, в которые можно перевести задачу, пустой список разрешает любой переход
+	Transitions []int     `json:"transitions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func IsStatusNameValid(name string) bool {
+	return name != "" && len(name) <= 50
+}
+
+// CanMoveTo сообщает, можно ли перевести задачу из этого статуса в статус id
+func (s *Status) CanMoveTo(id int) bool {
+	if len(s.Transitions) == 0 || s.ID == id {
+		return true
+	}
+	for _, transition := range s.Transitions {
+		if transition == id {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultStatuses — статусы, которые создаются пользователю при первом обращении к доске
+var DefaultStatuses = []Status{
+	{Name: "backlog"},
+	{Name: "todo"},
+	{Name: "in_progress"},
+	{Name: "review"},
+	{Name: "done", Done: true},
+}
+
+// FindStatus ищет статус по имени без учета регистра
+func FindStatus(statuses []Status, name string) *Status {
+	for i := range statuses {
+		if strings.EqualFold(statuses[i].Name, name) {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// ColumnOf возвращает индекс колонки задачи в statuses или -1, если статусов нет.
+// Задача без статуса или со статусом из другого набора попадает в первую колонку,
+// а выполненная — в первую завершающую колонку или в последнюю, если завершающих нет
+func ColumnOf(statuses []Status, task *Task) int {
+	if len(statuses) == 0 {
+		return -1
+	}
+
+	if task.StatusID != nil {
+		for i := range statuses {
+			if statuses[i].ID == *task.StatusID {
+				return i
+			}
+		}
+	}
+
+	if !task.Done {
+		return 0
+	}
+	for i := range statuses {
+		if statuses[i].Done {
+			return i
+		}
+	}
+	return len(statuses) - 1
+}
+
+type BoardColumn struct {
+	Status Status `json:"status"`
+	Tasks  []Task `json:"tasks"`
+}
+
+// Board — задачи, сгруппированные по статусам в порядке колонок
+type Board struct {
+	Columns []BoardColumn `json:"columns"`
+}
+
+// NewBoard раскладывает tasks по колонкам statuses, сохраняя порядок задач внутри колонки
+func NewBoard(statuses []Status, tasks []Task) *Board {
+	board := &Board{Columns: make([]BoardColumn, len(statuses))}
+	for i := range statuses {
+		board.Columns[i] = BoardColumn{Status: statuses[i], Tasks: []Task{}}
+	}
+
+	for i := range tasks {
+		if column := ColumnOf(statuses, &tasks[i]); column >= 0 {
+			board.Columns[column].Tasks = append(board.Columns[column].Tasks, tasks[i])
+		}
+	}
+
+	return board
+}
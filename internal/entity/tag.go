@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type Tag struct {
+	ID        int       `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TagMode string
+
+const (
+	TagModeAny TagMode = "any"
+	TagModeAll TagMode = "all"
+)
+
+func IsTagModeValid(mode TagMode) bool {
+	return mode == TagModeAny || mode == TagModeAll
+}
+
+func IsTagNameValid(name string) bool {
+	return name != "" && len(name) <= 50
+}
@@ -3,17 +3,228 @@ package entity
 import "time"
 
 type Task struct {
-	ID          int       `json:"id"`
-	Done        bool      `json:"done"`
-	UserID      string    `json:"user_id"`
-	Header      string    `json:"header"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	StartDate   time.Time `json:"start_date"`
+	ID          int        `json:"id"`
+	Done        bool       `json:"done"`
+	UserID      string     `json:"user_id"`
+	Header      string     `json:"header"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartDate   time.Time  `json:"start_date"`
+	Priority    Priority   `json:"priority"`
+	DueDate     *time.Time `json:"due_date"`
+	DueHasTime  bool       `json:"due_has_time"`
+	Overdue     bool       `json:"overdue"`
+	Tags        []Tag      `json:"tags"`
+	ProjectID   *int       `json:"project_id"`
+	ParentID    *int       `json:"parent_id"`
+	ChildCount  int        `json:"child_count"`
+	ChildDone   int        `json:"child_done"`
+	// Progress — доля завершенных подзадач в процентах
+	Progress int `json:"progress"`
+	// RRule — правило повторения RFC 5545, завершение повторения создает следующее
+	RRule      string `json:"rrule"`
+	SeriesID   *int   `json:"series_id"`
+	Occurrence int    `json:"occurrence"`
+	// StatusID — колонка доски, null означает колонку по умолчанию
+	StatusID *int `json:"status_id"`
+	// Position — ключ ручного порядка, задачи сортируются по нему побайтно
+	Position string `json:"position"`
+	// DeletedAt — время переноса задачи в корзину
+	DeletedAt *time.Time `json:"deleted_at"`
+	// Version растет при каждом изменении задачи и служит ETag для оптимистичной блокировки.
+	// В запросе на изменение ненулевая версия — ожидаемая текущая версия задачи
+	Version int `json:"version"`
+
+	// TagIDs задает новый набор меток задачи, nil оставляет метки без изменений
+	TagIDs []int `json:"-"`
+}
+
+// IsOverdue сообщает, просрочена ли незавершенная задача на момент now.
+// Срок без времени считается истекшим только после окончания дня
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.Done || t.DueDate == nil {
+		return false
+	}
+	if t.DueHasTime {
+		return t.DueDate.Before(now)
+	}
+	return t.DueDate.Before(StartOfDay(now))
+}
+
+// StartOfDay возвращает начало дня по настенному времени в UTC, так же как даты хранятся в базе
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WallClock переносит настенное время t в UTC без пересчета часового пояса
+func WallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
 }
 
-type ParamOption struct {
+type Priority string
+
+const (
+	PriorityNone   Priority = "none"
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+func IsPriorityValid(priority Priority) bool {
+	switch priority {
+	case PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+type SortField string
+
+const (
+	SortByID        SortField = "id"
+	SortByPriority  SortField = "priority"
+	SortByStartDate SortField = "start_date"
+	SortByDueDate   SortField = "due_date"
+	SortByCreatedAt SortField = "created_at"
+	SortByManual    SortField = "manual"
+)
+
+func IsSortFieldValid(field SortField) bool {
+	switch field {
+	case SortByID, SortByPriority, SortByStartDate, SortByDueDate, SortByCreatedAt, SortByManual:
+		return true
+	}
+	return false
+}
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+func IsSortOrderValid(order SortOrder) bool {
+	return order == SortAsc || order == SortDesc
+}
+
+// SearchResult — задача, найденная полнотекстовым поиском
+type SearchResult struct {
+	Task
+	Rank float32 `json:"rank"`
+	// Headline и Snippet — заголовок и фрагменты описания с совпадениями, выделенными тегом <mark>
+	Headline string `json:"headline"`
+	Snippet  string `json:"snippet"`
+}
+
+const (
+	DefaultPageSize = 3
+	MaxPageSize     = 100
+)
+
+// TaskQuery описывает выборку задач пользователя: фильтры, сортировку и пагинацию.
+// Репозиторий переводит его в один SQL-запрос, поэтому любые фильтры сочетаются между собой
+type TaskQuery struct {
+	UserID string
+	Filter TaskFilter
+	Sort   TaskSort
+
+	// Page — номер страницы начиная с 1, 0 отключает пагинацию
 	Page     int
+	PageSize int
+	// CursorMode включает курсорную пагинацию вместо номеров страниц.
+	// After и Before задают позицию, без них возвращается первая страница
+	CursorMode bool
+	After      *Cursor
+	Before     *Cursor
+}
+
+// IsPaged сообщает, ограничена ли выборка одной страницей
+func (q *TaskQuery) IsPaged() bool {
+	return q.Page > 0 || q.CursorMode
+}
+
+// TaskSort задает сортировку списка задач, по умолчанию от новых задач к старым,
+// ручной порядок по умолчанию идет сверху вниз
+type TaskSort struct {
+	Field SortField
+	Order SortOrder
+}
+
+// Normalize подставляет значения сортировки по умолчанию
+func (s TaskSort) Normalize() TaskSort {
+	if s.Field == "" {
+		s.Field = SortByID
+	}
+	if s.Order == "" && s.Field == SortByManual {
+		s.Order = SortAsc
+	}
+	if s.Order == "" {
+		s.Order = SortDesc
+	}
+	return s
+}
+
+type DueFilter string
+
+const (
+	DueOverdue  DueFilter = "overdue"
+	DueToday    DueFilter = "today"
+	DueThisWeek DueFilter = "week"
+)
+
+func IsDueFilterValid(due DueFilter) bool {
+	switch due {
+	case DueOverdue, DueToday, DueThisWeek:
+		return true
+	}
+	return false
+}
+
+// TaskFilter содержит условия отбора задач, все заданные условия должны выполняться одновременно
+type TaskFilter struct {
 	Status   *bool
-	DateTime time.Time
+	Priority *Priority
+
+	// Text — полнотекстовый запрос по заголовку и описанию
+	Text string
+
+	// Due отбирает задачи по сроку относительно Now
+	Due DueFilter
+	Now time.Time
+
+	// Tags отбирает задачи с любой (TagModeAny) или со всеми (TagModeAll) из перечисленных меток
+	Tags    []int
+	TagMode TagMode
+
+	ProjectID *int
+
+	// StartRange, CreatedRange и DueRange отбирают задачи по дате начала, создания и сроку
+	StartRange   TimeRange
+	CreatedRange TimeRange
+	DueRange     TimeRange
+
+	// Deleted отбирает задачи из корзины вместо активных
+	Deleted bool
+}
+
+// IsEmpty сообщает, что фильтр не сужает выборку: ни одно условие не задано
+func (f TaskFilter) IsEmpty() bool {
+	return f.Status == nil && f.Priority == nil && f.Text == "" && f.Due == "" && len(f.Tags) == 0 && f.ProjectID == nil &&
+		f.StartRange.IsEmpty() && f.CreatedRange.IsEmpty() && f.DueRange.IsEmpty()
+}
+
+// TimeRange — интервал дат, обе границы включены, nil означает отсутствие границы
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+func (r TimeRange) IsEmpty() bool {
+	return r.From == nil && r.To == nil
+}
+
+func (r TimeRange) IsValid() bool {
+	return r.From == nil || r.To == nil || !r.To.Before(*r.From)
 }
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"net/http"
+	"time"
+)
+
+type TokenScope string
+
+const (
+	// TokenScopeRead разрешает только чтение, TokenScopeWrite — любые запросы
+	TokenScopeRead  TokenScope = "read"
+	TokenScopeWrite TokenScope = "write"
+)
+
+func IsTokenScopeValid(scope TokenScope) bool {
+	return scope == TokenScopeRead || scope == TokenScopeWrite
+}
+
+// AccessToken — персональный токен доступа для скриптов и CI. Сам токен показывается один раз при создании,
+// хранится только его хеш
+type AccessToken struct {
+	ID     string     `json:"id"`
+	UserID string     `json:"user_id"`
+	Name   string     `json:"name"`
+	Scope  TokenScope `json:"scope" enums:"read,write"`
+	// Prefix — начало токена, по нему токен можно узнать в списке
+	Prefix string `json:"prefix"`
+	Hash   string `json:"-"`
+	// ExpiresAt равен nil для бессрочного токена
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	Role string `json:"-"`
+}
+
+// Allows сообщает, разрешает ли область действия токена запрос с методом method
+func (t *AccessToken) Allows(method string) bool {
+	if t.Scope == TokenScopeWrite {
+		return true
+	}
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// NewAccessToken — созданный токен вместе с секретом, который больше нигде не сохраняется
+type NewAccessToken struct {
+	AccessToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/idempotency"
+	"go-todolist-sber/pkg/postgres"
+	"time"
+)
+
+type idempotencyRepository struct {
+	*postgres.Postgres
+}
+
+func NewIdempotencyRepository(postgres *postgres.Postgres) idempotency.IdempotencyRepository {
+	return &idempotencyRepository{
+		postgres,
+	}
+}
+
+// Create занимает ключ за первым запросом. Истекший ключ занимается заново, действующий дает ErrUniqueViolation
+func (i *idempotencyRepository) Create(ctx context.Context, key *entity.IdempotencyKey) error {
+	query := `insert into idempotency_key (id_user, key, request_hash, created_at, expires_at)
+				values ($1, $2, $3, $4, $5)
+				on conflict (id_user, key) do update set
+					request_hash = excluded.request_hash,
+					status_code = 0,
+					header = null,
+					response = null,
+					created_at = excluded.created_at,
+					expires_at = excluded.expires_at
+				where idempotency_key.expires_at <= excluded.created_at`
+
+	tag, err := i.Pool.Exec(ctx, query, key.UserID, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.ErrUniqueViolation
+	}
+	return nil
+}
+
+func (i *idempotencyRepository) Get(ctx context.Context, userID string, key string) (*entity.IdempotencyKey, error) {
+	query := `select id_user, key, request_hash, status_code, header, response, created_at, expires_at
+				from idempotency_key where id_user = $1 and key = $2`
+
+	var (
+		stored entity.IdempotencyKey
+		header []byte
+	)
+	err := i.Pool.QueryRow(ctx, query, userID, key).Scan(&stored.UserID, &stored.Key, &stored.RequestHash,
+		&stored.StatusCode, &header, &stored.Response, &stored.CreatedAt, &stored.ExpiresAt)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(header) > 0 {
+		if err := json.Unmarshal(header, &stored.Header); err != nil {
+			return nil, err
+		}
+	}
+	return &stored, nil
+}
+
+// Complete сохраняет ответ на запрос, занявший ключ
+func (i *idempotencyRepository) Complete(ctx context.Context, key *entity.IdempotencyKey) error {
+	query := `update idempotency_key set status_code = $1, header = $2, response = $3
+				where id_user = $4 and key = $5 and request_hash = $6`
+
+	header, err := json.Marshal(key.Header)
+	if err != nil {
+		return err
+	}
+
+	tag, err := i.Pool.Exec(ctx, query, key.StatusCode, header, key.Response, key.UserID, key.Key, key.RequestHash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.ErrNoRows
+	}
+	return nil
+}
+
+// Delete освобождает ключ, ответ на который сохранять не нужно
+func (i *idempotencyRepository) Delete(ctx context.Context, userID string, key string) error {
+	query := `delete from idempotency_key where id_user = $1 and key = $2 and status_code = 0`
+
+	_, err := i.Pool.Exec(ctx, query, userID, key)
+	return err
+}
+
+// Purge удаляет ключи, истекшие раньше before, и возвращает их количество
+func (i *idempotencyRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	query := `delete from idempotency_key where expires_at < $1`
+
+	tag, err := i.Pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,16 @@
+package idempotency
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+	"time"
+)
+
+//go:generate mockgen -source storage.go -destination mock/idempotency_repository_mock.go -package mock
+type IdempotencyRepository interface {
+	Create(ctx context.Context, key *entity.IdempotencyKey) error
+	Get(ctx context.Context, userID string, key string) (*entity.IdempotencyKey, error)
+	Complete(ctx context.Context, key *entity.IdempotencyKey) error
+	Delete(ctx context.Context, userID string, key string) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
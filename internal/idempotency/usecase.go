@@ -0,0 +1,12 @@
+package idempotency
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+type IdempotencyUsecase interface {
+	Begin(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error)
+	Complete(ctx context.Context, key *entity.IdempotencyKey) error
+	Abort(ctx context.Context, key *entity.IdempotencyKey) error
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/idempotency"
+	"time"
+)
+
+type idempotencyUsecase struct {
+	idempotencyRepo idempotency.IdempotencyRepository
+	ttl             time.Duration
+	now             func() time.Time
+}
+
+func NewIdempotencyUsecase(idempotencyRepo idempotency.IdempotencyRepository, ttl time.Duration) idempotency.IdempotencyUsecase {
+	return &idempotencyUsecase{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+		now:             time.Now,
+	}
+}
+
+// Begin занимает ключ за запросом key. Если ключ свободен, возвращается nil и запрос нужно выполнить,
+// если по ключу уже сохранен ответ на тот же запрос — возвращается этот ответ
+func (i *idempotencyUsecase) Begin(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	key.CreatedAt = entity.WallClock(i.now())
+	key.ExpiresAt = key.CreatedAt.Add(i.ttl)
+
+	err := i.idempotencyRepo.Create(ctx, key)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, apperror.ErrUniqueViolation) {
+		return nil, err
+	}
+
+	stored, err := i.idempotencyRepo.Get(ctx, key.UserID, key.Key)
+	// ключ освободили между попытками занять и прочитать его, клиенту достаточно повторить запрос
+	if errors.Is(err, apperror.ErrNoRows) {
+		return nil, apperror.ErrRequestInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.RequestHash != key.RequestHash {
+		return nil, apperror.ErrIdempotencyKeyReused
+	}
+	if !stored.Completed() {
+		return nil, apperror.ErrRequestInProgress
+	}
+	return stored, nil
+}
+
+// Complete сохраняет ответ на запрос, занявший ключ
+func (i *idempotencyUsecase) Complete(ctx context.Context, key *entity.IdempotencyKey) error {
+	return i.idempotencyRepo.Complete(ctx, key)
+}
+
+// Abort освобождает ключ, чтобы запрос можно было повторить, например после ошибки сервера
+func (i *idempotencyUsecase) Abort(ctx context.Context, key *entity.IdempotencyKey) error {
+	return i.idempotencyRepo.Delete(ctx, key.UserID, key.Key)
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/idempotency/mock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyUsecase_Begin(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ttl := 24 * time.Hour
+	completed := &entity.IdempotencyKey{UserID: "uuid", Key: "key", RequestHash: "hash", StatusCode: http.StatusCreated,
+		Header: http.Header{"Content-Type": {"application/json"}}, Response: []byte(`{"id":1}`)}
+
+	type mockBehavior func(r *mock.MockIdempotencyRepository)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         *entity.IdempotencyKey
+		wantErr      error
+	}{
+		{
+			name: "new key",
+			mockBehavior: func(r *mock.MockIdempotencyRepository) {
+				r.EXPECT().Create(context.Background(), &entity.IdempotencyKey{UserID: "uuid", Key: "key", RequestHash: "hash",
+					CreatedAt: now, ExpiresAt: now.Add(ttl)}).Return(nil)
+			},
+			want:    nil,
+			wantErr: nil,
+		},
+		{
+			name: "replay",
+			mockBehavior: func(r *mock.MockIdempotencyRepository) {
+				r.EXPECT().Create(context.Background(), gomock.Any()).Return(apperror.ErrUniqueViolation)
+				r.EXPECT().Get(context.Background(), "uuid", "key").Return(completed, nil)
+			},
+			want:    completed,
+			wantErr: nil,
+		},
+		{
+			name: "different request",
+			mockBehavior: func(r *mock.MockIdempotencyRepository) {
+				r.EXPECT().Create(context.Background(), gomock.Any()).Return(apperror.ErrUniqueViolation)
+				r.EXPECT().Get(context.Background(), "uuid", "key").
+					Return(&entity.IdempotencyKey{UserID: "uuid", Key: "key", RequestHash: "other", StatusCode: http.StatusCreated}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "first request in progress",
+			mockBehavior: func(r *mock.MockIdempotencyRepository) {
+				r.EXPECT().Create(context.Background(), gomock.Any()).Return(apperror.ErrUniqueViolation)
+				r.EXPECT().Get(context.Background(), "uuid", "key").Return(&entity.IdempotencyKey{UserID: "uuid", Key: "key", RequestHash: "hash"}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrRequestInProgress,
+		},
+		{
+			name: "storage error",
+			mockBehavior: func(r *mock.MockIdempotencyRepository) {
+				r.EXPECT().Create(context.Background(), gomock.Any()).Return(errors.New("connection refused"))
+			},
+			want:    nil,
+			wantErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockIdempotencyRepo := mock.NewMockIdempotencyRepository(ctrl)
+			tt.mockBehavior(mockIdempotencyRepo)
+
+			idempotencyUsecase := &idempotencyUsecase{idempotencyRepo: mockIdempotencyRepo, ttl: ttl, now: func() time.Time { return now }}
+			stored, err := idempotencyUsecase.Begin(context.Background(), &entity.IdempotencyKey{UserID: "uuid", Key: "key", RequestHash: "hash"})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, stored)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/apperror"
+	pgxError "go-todolist-sber/internal/apperror/pgx_errors"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/jwtauth"
+	"go-todolist-sber/pkg/postgres"
+	"time"
+)
+
+type refreshTokenRepository struct {
+	*postgres.Postgres
+}
+
+func NewRefreshTokenRepository(postgres *postgres.Postgres) jwtauth.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		postgres,
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	query := `insert into refresh_token (family_id, family_created_at, user_id, token_hash, user_agent, ip, expires_at, created_at)
+				values ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.Pool.Exec(ctx, query, token.FamilyID, token.FamilyCreatedAt, token.UserID, token.Hash, token.UserAgent, token.IP,
+		token.ExpiresAt, token.CreatedAt)
+	errCode := pgxError.ErrorCode(err)
+	if errCode == pgxError.ForeignKeyViolation {
+		return apperror.ErrForeignKeyViolation
+	}
+	if errCode == pgxError.UniqueViolation {
+		return apperror.ErrUniqueViolation
+	}
+	return err
+}
+
+// GetByHash возвращает токен по хешу вместе с текущей ролью владельца
+func (r *refreshTokenRepository) GetByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	query := `select t.id, t.family_id, t.family_created_at, t.user_id, t.token_hash, t.user_agent, t.ip, t.expires_at, t.used_at, t.created_at, u.role
+				from refresh_token t
+				join "user" u on t.user_id = u.id
+				where t.token_hash = $1`
+
+	var token entity.RefreshToken
+	err := r.Pool.QueryRow(ctx, query, hash).Scan(&token.ID, &token.FamilyID, &token.FamilyCreatedAt, &token.UserID, &token.Hash, &token.UserAgent,
+		&token.IP, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt, &token.Role)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed отмечает токен использованным. false означает, что токен уже использовал другой запрос
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id string, usedAt time.Time) (bool, error) {
+	query := `update refresh_token set used_at = $1 where id = $2 and used_at is null`
+
+	tag, err := r.Pool.Exec(ctx, query, usedAt, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// DeleteFamily отзывает все токены, выданные от одного входа
+func (r *refreshTokenRepository) DeleteFamily(ctx context.Context, familyID string) error {
+	query := `delete from refresh_token where family_id = $1`
+
+	_, err := r.Pool.Exec(ctx, query, familyID)
+	return err
+}
+
+// Purge удаляет токены, истекшие раньше before, и возвращает их количество
+func (r *refreshTokenRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	query := `delete from refresh_token where expires_at < $1`
+
+	tag, err := r.Pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
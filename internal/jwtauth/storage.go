@@ -0,0 +1,15 @@
+package jwtauth
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+	"time"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	GetByHash(ctx context.Context, hash string) (*entity.RefreshToken, error)
+	MarkUsed(ctx context.Context, id string, usedAt time.Time) (bool, error)
+	DeleteFamily(ctx context.Context, familyID string) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,15 @@
+package jwtauth
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/pkg/jwt"
+)
+
+type JWTUsecase interface {
+	Login(ctx context.Context, user *entity.User, device entity.Device) (*entity.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string, device entity.Device) (*entity.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	Verify(accessToken string) (*jwt.Claims, error)
+	JWKS() jwt.JWKS
+}
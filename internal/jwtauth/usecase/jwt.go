@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/jwtauth"
+	"go-todolist-sber/pkg/jwt"
+	"io"
+	"time"
+)
+
+const tokenType = "Bearer"
+
+// jwtUsecase выдает токены входа. maxLifetime ограничивает жизнь семьи refresh-токенов от входа,
+// обновления ее не продлевают
+type jwtUsecase struct {
+	refreshTokenRepo jwtauth.RefreshTokenRepository
+	keys             *jwt.KeySet
+	issuer           string
+	accessTTL        time.Duration
+	refreshTTL       time.Duration
+	maxLifetime      time.Duration
+	random           io.Reader
+	now              func() time.Time
+}
+
+func NewJWTUsecase(refreshTokenRepo jwtauth.RefreshTokenRepository, keys *jwt.KeySet, issuer string, accessTTL, refreshTTL, maxLifetime time.Duration) jwtauth.JWTUsecase {
+	return &jwtUsecase{
+		refreshTokenRepo: refreshTokenRepo,
+		keys:             keys,
+		issuer:           issuer,
+		accessTTL:        accessTTL,
+		refreshTTL:       refreshTTL,
+		maxLifetime:      maxLifetime,
+		random:           rand.Reader,
+		now:              time.Now,
+	}
+}
+
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Login открывает новую семью refresh-токенов для входа пользователя с устройства
+func (j *jwtUsecase) Login(ctx context.Context, user *entity.User, device entity.Device) (*entity.TokenPair, error) {
+	return j.issue(ctx, user.ID, user.Role, uuid.New().String(), entity.WallClock(j.now()), device)
+}
+
+// Refresh обменивает refresh-токен на новую пару. Повторное предъявление уже использованного токена
+// означает, что он утек, поэтому вся семья отзывается и пользователю нужно войти заново
+func (j *jwtUsecase) Refresh(ctx context.Context, refreshToken string, device entity.Device) (*entity.TokenPair, error) {
+	if refreshToken == "" {
+		return nil, apperror.ErrInvalidToken
+	}
+
+	stored, err := j.refreshTokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, apperror.ErrNoRows) {
+		return nil, apperror.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.UsedAt != nil {
+		return nil, j.revokeFamily(ctx, stored.FamilyID)
+	}
+
+	now := entity.WallClock(j.now())
+	if !now.Before(stored.ExpiresAt) {
+		return nil, apperror.ErrInvalidToken
+	}
+
+	// токен мог успеть использовать параллельный запрос, это тоже повторное предъявление
+	ok, err := j.refreshTokenRepo.MarkUsed(ctx, stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, j.revokeFamily(ctx, stored.FamilyID)
+	}
+
+	return j.issue(ctx, stored.UserID, stored.Role, stored.FamilyID, stored.FamilyCreatedAt, device)
+}
+
+// Logout отзывает семью refresh-токена, выданные access token действуют до конца своего срока
+func (j *jwtUsecase) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := j.refreshTokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, apperror.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return j.refreshTokenRepo.DeleteFamily(ctx, stored.FamilyID)
+}
+
+// Verify проверяет access token только по подписи и сроку, без обращения к базе
+func (j *jwtUsecase) Verify(accessToken string) (*jwt.Claims, error) {
+	claims, err := j.keys.Verify(accessToken, j.now())
+	if err != nil || claims.Issuer != j.issuer || claims.Subject == "" {
+		return nil, apperror.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (j *jwtUsecase) JWKS() jwt.JWKS {
+	return j.keys.JWKS()
+}
+
+func (j *jwtUsecase) revokeFamily(ctx context.Context, familyID string) error {
+	if err := j.refreshTokenRepo.DeleteFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return apperror.ErrRefreshTokenReused
+}
+
+// expiresAt возвращает срок нового refresh-токена: refreshTTL от now, но не позже предельного времени жизни семьи
+func (j *jwtUsecase) expiresAt(familyCreatedAt, now time.Time) time.Time {
+	expiresAt := now.Add(j.refreshTTL)
+	if limit := familyCreatedAt.Add(j.maxLifetime); limit.Before(expiresAt) {
+		return limit
+	}
+	return expiresAt
+}
+
+func (j *jwtUsecase) issue(ctx context.Context, userID, role, familyID string, familyCreatedAt time.Time, device entity.Device) (*entity.TokenPair, error) {
+	now := j.now()
+
+	accessToken, err := j.keys.Sign(jwt.Claims{
+		Issuer:    j.issuer,
+		Subject:   userID,
+		Role:      role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(j.accessTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	random := make([]byte, 32)
+	if _, err := io.ReadFull(j.random, random); err != nil {
+		return nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(random)
+
+	createdAt := entity.WallClock(now)
+	err = j.refreshTokenRepo.Create(ctx, &entity.RefreshToken{
+		FamilyID:        familyID,
+		FamilyCreatedAt: familyCreatedAt,
+		UserID:          userID,
+		Hash:            hashToken(refreshToken),
+		UserAgent:       device.UserAgent,
+		IP:              device.IP,
+		ExpiresAt:       j.expiresAt(familyCreatedAt, createdAt),
+		CreatedAt:       createdAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TokenPair{
+		AccessToken:  accessToken,
+		TokenType:    tokenType,
+		ExpiresIn:    int64(j.accessTTL.Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/jwtauth/mock"
+	"go-todolist-sber/pkg/jwt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKeys(t *testing.T) *jwt.KeySet {
+	key, err := jwt.ParseKey("test:" + base64.RawURLEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(t, err)
+	keys, err := jwt.NewKeySet(key)
+	require.NoError(t, err)
+	return keys
+}
+
+func newTestUsecase(t *testing.T, repo *mock.MockRefreshTokenRepository, now time.Time) *jwtUsecase {
+	return &jwtUsecase{
+		refreshTokenRepo: repo,
+		keys:             testKeys(t),
+		issuer:           "todolist",
+		accessTTL:        15 * time.Minute,
+		refreshTTL:       30 * 24 * time.Hour,
+		maxLifetime:      90 * 24 * time.Hour,
+		random:           bytes.NewReader(make([]byte, 32)),
+		now:              func() time.Time { return now },
+	}
+}
+
+func TestJWTUsecase_Login(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	refreshToken := strings.Repeat("A", 43)
+	device := entity.Device{UserAgent: "curl/8.0", IP: "10.0.0.1"}
+
+	ctrl := gomock.NewController(t)
+	mockRefreshTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRefreshTokenRepo.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, token *entity.RefreshToken) error {
+		assert.NotEmpty(t, token.FamilyID)
+		assert.Equal(t, &entity.RefreshToken{FamilyID: token.FamilyID, UserID: "uuid", Hash: hashToken(refreshToken), UserAgent: "curl/8.0",
+			IP: "10.0.0.1", FamilyCreatedAt: now, ExpiresAt: now.Add(30 * 24 * time.Hour), CreatedAt: now}, token)
+		return nil
+	})
+
+	jwtUsecase := newTestUsecase(t, mockRefreshTokenRepo, now)
+	pair, err := jwtUsecase.Login(context.Background(), &entity.User{ID: "uuid", Role: "admin"}, device)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", pair.TokenType)
+	assert.Equal(t, int64(900), pair.ExpiresIn)
+	assert.Equal(t, refreshToken, pair.RefreshToken)
+
+	claims, err := jwtUsecase.Verify(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, &jwt.Claims{Issuer: "todolist", Subject: "uuid", Role: "admin", IssuedAt: now.Unix(),
+		ExpiresAt: now.Add(15 * time.Minute).Unix()}, claims)
+}
+
+func TestJWTUsecase_Refresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	used := now.Add(-time.Minute)
+	secret := "refresh"
+	stored := func(usedAt *time.Time, expiresAt time.Time) *entity.RefreshToken {
+		return &entity.RefreshToken{ID: "id", FamilyID: "family", UserID: "uuid", Role: "user", UsedAt: usedAt,
+			FamilyCreatedAt: now.Add(-24 * time.Hour), ExpiresAt: expiresAt}
+	}
+
+	type mockBehavior func(r *mock.MockRefreshTokenRepository)
+
+	tests := []struct {
+		name         string
+		secret       string
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name:   "ok",
+			secret: secret,
+			mockBehavior: func(r *mock.MockRefreshTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(stored(nil, now.Add(time.Hour)), nil)
+				r.EXPECT().MarkUsed(context.Background(), "id", now).Return(true, nil)
+				r.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, token *entity.RefreshToken) error {
+					assert.Equal(t, "family", token.FamilyID)
+					assert.Equal(t, "uuid", token.UserID)
+					assert.Equal(t, now.Add(-24*time.Hour), token.FamilyCreatedAt)
+					assert.Equal(t, now.Add(30*24*time.Hour), token.ExpiresAt)
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "family lifetime caps expiry",
+			secret: secret,
+			mockBehavior: func(r *mock.MockRefreshTokenRepository) {
+				old := stored(nil, now.Add(time.Hour))
+				old.FamilyCreatedAt = now.Add(-80 * 24 * time.Hour)
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(old, nil)
+				r.EXPECT().MarkUsed(context.Background(), "id", now).Return(true, nil)
+				r.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, token *entity.RefreshToken) error {
+					assert.Equal(t, old.FamilyCreatedAt, token.FamilyCreatedAt)
+					assert.Equal(t, now.Add(10*24*time.Hour), token.ExpiresAt)
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "reused token revokes the family",
+			secret: secret,
+			mockBehavior: func(r *mock.MockRefreshTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(stored(&used, now.Add(time.Hour)), nil)
+				r.EXPECT().DeleteFamily(context.Background(), "family").Return(nil)
+			},
+			wantErr: apperror.ErrRefreshTokenReused,
+		},
+		{
+			name:   "concurrent refresh revokes the family",
+			secret: secret,
+			mockBehavior: func(r *mock.MockRefreshTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(stored(nil, now.Add(time.Hour)), nil)
+				r.EXPECT().MarkUsed(context.Background(), "id", now).Return(false, nil)
+				r.EXPECT().DeleteFamily(context.Background(), "family").Return(nil)
+			},
+			wantErr: apperror.ErrRefreshTokenReused,
+		},
+		{
+			name:   "expired",
+			secret: secret,
+			mockBehavior: func(r *mock.MockRefreshTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(stored(nil, now), nil)
+			},
+			wantErr: apperror.ErrInvalidToken,
+		},
+		{
+			name:   "unknown token",
+			secret: secret,
+			mockBehavior: func(r *mock.MockRefreshTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(nil, apperror.ErrNoRows)
+			},
+			wantErr: apperror.ErrInvalidToken,
+		},
+		{
+			name:         "empty token",
+			secret:       "",
+			mockBehavior: func(r *mock.MockRefreshTokenRepository) {},
+			wantErr:      apperror.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRefreshTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+			tt.mockBehavior(mockRefreshTokenRepo)
+
+			jwtUsecase := newTestUsecase(t, mockRefreshTokenRepo, now)
+			pair, err := jwtUsecase.Refresh(context.Background(), tt.secret, entity.Device{})
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				assert.Nil(t, pair)
+				return
+			}
+
+			claims, err := jwtUsecase.Verify(pair.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, "uuid", claims.Subject)
+			assert.Equal(t, "user", claims.Role)
+		})
+	}
+}
+
+func TestJWTUsecase_Logout(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	mockRefreshTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRefreshTokenRepo.EXPECT().GetByHash(context.Background(), hashToken("refresh")).Return(&entity.RefreshToken{ID: "id", FamilyID: "family"}, nil)
+	mockRefreshTokenRepo.EXPECT().DeleteFamily(context.Background(), "family").Return(nil)
+	mockRefreshTokenRepo.EXPECT().GetByHash(context.Background(), hashToken("revoked")).Return(nil, apperror.ErrNoRows)
+
+	jwtUsecase := newTestUsecase(t, mockRefreshTokenRepo, now)
+	assert.NoError(t, jwtUsecase.Logout(context.Background(), "refresh"))
+	assert.NoError(t, jwtUsecase.Logout(context.Background(), "revoked"))
+	assert.NoError(t, jwtUsecase.Logout(context.Background(), ""))
+}
+
+func TestJWTUsecase_Verify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	keys := testKeys(t)
+	sign := func(claims jwt.Claims) string {
+		token, err := keys.Sign(claims)
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "ok",
+			token: sign(jwt.Claims{Issuer: "todolist", Subject: "uuid", ExpiresAt: now.Add(time.Minute).Unix()}),
+		},
+		{
+			name:    "expired",
+			token:   sign(jwt.Claims{Issuer: "todolist", Subject: "uuid", ExpiresAt: now.Unix()}),
+			wantErr: apperror.ErrInvalidToken,
+		},
+		{
+			name:    "other issuer",
+			token:   sign(jwt.Claims{Issuer: "other", Subject: "uuid", ExpiresAt: now.Add(time.Minute).Unix()}),
+			wantErr: apperror.ErrInvalidToken,
+		},
+		{
+			name:    "without subject",
+			token:   sign(jwt.Claims{Issuer: "todolist", ExpiresAt: now.Add(time.Minute).Unix()}),
+			wantErr: apperror.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtUsecase := newTestUsecase(t, nil, now)
+			_, err := jwtUsecase.Verify(tt.token)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
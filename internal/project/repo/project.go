@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/apperror"
+	pgxError "go-todolist-sber/internal/apperror/pgx_errors"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/project"
+	"go-todolist-sber/pkg/postgres"
+)
+
+const projectColumns = `id, id_user, name, archived, created_at`
+
+type projectRepository struct {
+	*postgres.Postgres
+}
+
+func NewProjectRepository(postgres *postgres.Postgres) project.ProjectRepository {
+	return &projectRepository{
+		postgres,
+	}
+}
+
+func (p *projectRepository) collectRow(row pgx.Row) (*entity.Project, error) {
+	var project entity.Project
+	err := row.Scan(&project.ID, &project.UserID, &project.Name, &project.Archived, &project.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	errCode := pgxError.ErrorCode(err)
+	if errCode == pgxError.ForeignKeyViolation {
+		return nil, apperror.ErrForeignKeyViolation
+	}
+	if errCode == pgxError.UniqueViolation {
+		return nil, apperror.ErrUniqueViolation
+	}
+	return &project, err
+}
+
+func (p *projectRepository) collectRows(rows pgx.Rows) ([]entity.Project, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Project, error) {
+		project, err := p.collectRow(row)
+		if err != nil {
+			return entity.Project{}, err
+		}
+		return *project, nil
+	})
+}
+
+func (p *projectRepository) Create(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	query := `insert into project (id_user,name) values ($1,$2) returning ` + projectColumns
+
+	row := p.Pool.QueryRow(ctx, query, project.UserID, project.Name)
+	return p.collectRow(row)
+}
+
+func (p *projectRepository) UpdateName(ctx context.Context, id int, name string) (*entity.Project, error) {
+	query := `update project set name = $1 where id = $2 returning ` + projectColumns
+
+	row := p.Pool.QueryRow(ctx, query, name, id)
+	return p.collectRow(row)
+}
+
+func (p *projectRepository) UpdateArchived(ctx context.Context, id int, archived bool) (*entity.Project, error) {
+	query := `update project set archived = $1 where id = $2 returning ` + projectColumns
+
+	row := p.Pool.QueryRow(ctx, query, archived, id)
+	return p.collectRow(row)
+}
+
+func (p *projectRepository) GetByUserID(ctx context.Context, userID string, archived *bool) ([]entity.Project, error) {
+	query := `select ` + projectColumns + ` from project
+				where id_user = $1 and ($2::bool is null or archived = $2)
+				order by name`
+
+	rows, err := p.Pool.Query(ctx, query, userID, archived)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.collectRows(rows)
+}
+
+func (p *projectRepository) GetByID(ctx context.Context, id int) (*entity.Project, error) {
+	query := `select ` + projectColumns + ` from project where id = $1`
+
+	row := p.Pool.QueryRow(ctx, query, id)
+	return p.collectRow(row)
+}
+
+func (p *projectRepository) DeleteByID(ctx context.Context, id int) error {
+	query := `delete from project where id = $1`
+
+	_, err := p.Pool.Exec(ctx, query, id)
+	return err
+}
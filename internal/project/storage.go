@@ -0,0 +1,16 @@
+package project
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+//go:generate mockgen -source storage.go -destination mock/project_repository_mock.go -package mock
+type ProjectRepository interface {
+	Create(ctx context.Context, project *entity.Project) (*entity.Project, error)
+	UpdateName(ctx context.Context, id int, name string) (*entity.Project, error)
+	UpdateArchived(ctx context.Context, id int, archived bool) (*entity.Project, error)
+	GetByUserID(ctx context.Context, userID string, archived *bool) ([]entity.Project, error)
+	GetByID(ctx context.Context, id int) (*entity.Project, error)
+	DeleteByID(ctx context.Context, id int) error
+}
@@ -0,0 +1,15 @@
+package project
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+type ProjectUsecase interface {
+	CreateProject(ctx context.Context, project *entity.Project) (*entity.Project, error)
+	RenameProject(ctx context.Context, id int, name string) (*entity.Project, error)
+	ArchiveProject(ctx context.Context, id int, archived bool) (*entity.Project, error)
+	GetUserProjects(ctx context.Context, userID string, archived *bool) ([]entity.Project, error)
+	DeleteProject(ctx context.Context, id int) error
+	IsEqualUserID(ctx context.Context, contextUserID string, projectID int) (bool, error)
+}
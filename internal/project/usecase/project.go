@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/project"
+	"strings"
+)
+
+type projectUsecase struct {
+	projectRepo project.ProjectRepository
+}
+
+func NewProjectUsecase(projectRepo project.ProjectRepository) project.ProjectUsecase {
+	return &projectUsecase{
+		projectRepo: projectRepo,
+	}
+}
+
+func (p *projectUsecase) CreateProject(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	project.Name = strings.TrimSpace(project.Name)
+	if !entity.IsProjectNameValid(project.Name) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	project, err := p.projectRepo.Create(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (p *projectUsecase) RenameProject(ctx context.Context, id int, name string) (*entity.Project, error) {
+	name = strings.TrimSpace(name)
+	if !entity.IsProjectNameValid(name) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	project, err := p.projectRepo.UpdateName(ctx, id, name)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (p *projectUsecase) ArchiveProject(ctx context.Context, id int, archived bool) (*entity.Project, error) {
+	project, err := p.projectRepo.UpdateArchived(ctx, id, archived)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (p *projectUsecase) GetUserProjects(ctx context.Context, userID string, archived *bool) ([]entity.Project, error) {
+	projects, err := p.projectRepo.GetByUserID(ctx, userID, archived)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (p *projectUsecase) DeleteProject(ctx context.Context, id int) error {
+	if err := p.projectRepo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *projectUsecase) IsEqualUserID(ctx context.Context, contextUserID string, projectID int) (bool, error) {
+	data, err := p.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	if data.UserID != contextUserID {
+		return false, nil
+	}
+
+	return true, nil
+}
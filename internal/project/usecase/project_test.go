@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/project/mock"
+	"testing"
+)
+
+func TestProjectUsecase_CreateProject(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *mock.MockProjectRepository, project *entity.Project)
+
+	tests := []struct {
+		name         string
+		project      *entity.Project
+		mockBehavior mockBehavior
+		want         *entity.Project
+		wantErr      error
+	}{
+		{
+			name:    "ok",
+			project: &entity.Project{UserID: "uuid", Name: "Home "},
+			mockBehavior: func(r *mock.MockProjectRepository, project *entity.Project) {
+				r.EXPECT().Create(context.Background(), gomock.Eq(&entity.Project{UserID: "uuid", Name: "Home"})).
+					Return(&entity.Project{ID: 1, UserID: "uuid", Name: "Home"}, nil)
+			},
+			want:    &entity.Project{ID: 1, UserID: "uuid", Name: "Home"},
+			wantErr: nil,
+		},
+		{
+			name:         "empty name",
+			project:      &entity.Project{UserID: "uuid"},
+			mockBehavior: func(r *mock.MockProjectRepository, project *entity.Project) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectRepo := mock.NewMockProjectRepository(ctrl)
+			tt.mockBehavior(mockProjectRepo, tt.project)
+
+			projectUsecase := NewProjectUsecase(mockProjectRepo)
+			project, err := projectUsecase.CreateProject(context.Background(), tt.project)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, project)
+		})
+	}
+}
+
+func TestProjectUsecase_RenameProject(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *mock.MockProjectRepository, id int, name string)
+
+	tests := []struct {
+		name         string
+		id           int
+		projectName  string
+		mockBehavior mockBehavior
+		want         *entity.Project
+		wantErr      error
+	}{
+		{
+			name:        "ok",
+			id:          1,
+			projectName: "Work",
+			mockBehavior: func(r *mock.MockProjectRepository, id int, name string) {
+				r.EXPECT().UpdateName(context.Background(), id, name).Return(&entity.Project{ID: id, Name: name}, nil)
+			},
+			want:    &entity.Project{ID: 1, Name: "Work"},
+			wantErr: nil,
+		},
+		{
+			name:        "not found",
+			id:          2,
+			projectName: "Work",
+			mockBehavior: func(r *mock.MockProjectRepository, id int, name string) {
+				r.EXPECT().UpdateName(context.Background(), id, name).Return(nil, apperror.ErrNoRows)
+			},
+			want:    nil,
+			wantErr: apperror.ErrNoRows,
+		},
+		{
+			name:         "empty name",
+			id:           3,
+			projectName:  "",
+			mockBehavior: func(r *mock.MockProjectRepository, id int, name string) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectRepo := mock.NewMockProjectRepository(ctrl)
+			tt.mockBehavior(mockProjectRepo, tt.id, tt.projectName)
+
+			projectUsecase := NewProjectUsecase(mockProjectRepo)
+			project, err := projectUsecase.RenameProject(context.Background(), tt.id, tt.projectName)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, project)
+		})
+	}
+}
+
+func TestProjectUsecase_IsEqualUserID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectRepo := mock.NewMockProjectRepository(ctrl)
+	mockProjectRepo.EXPECT().GetByID(context.Background(), 1).Return(&entity.Project{ID: 1, UserID: "other"}, nil)
+
+	projectUsecase := NewProjectUsecase(mockProjectRepo)
+	equal, err := projectUsecase.IsEqualUserID(context.Background(), "uuid", 1)
+	assert.NoError(t, err)
+	assert.False(t, equal)
+}
@@ -0,0 +1,11 @@
+package reminder
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+//go:generate mockgen -source notifier.go -destination mock/notifier_mock.go -package mock
+type Notifier interface {
+	Notify(ctx context.Context, notification *entity.Notification) error
+}
@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder"
+	"go-todolist-sber/pkg/logger"
+)
+
+type logNotifier struct {
+	log *logger.Logger
+}
+
+// NewLogNotifier пишет напоминания в лог сервера
+func NewLogNotifier(log *logger.Logger) reminder.Notifier {
+	return &logNotifier{
+		log: log,
+	}
+}
+
+func (l *logNotifier) Notify(ctx context.Context, notification *entity.Notification) error {
+	l.log.Info("Reminder %d for user %s: task %d %q at %s", notification.ReminderID, notification.UserID,
+		notification.TaskID, notification.Header, notification.FireAt.Format("02.01.2006 15:04"))
+	return nil
+}
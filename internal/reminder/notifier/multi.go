@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder"
+)
+
+type multiNotifier []reminder.Notifier
+
+// NewMultiNotifier отправляет напоминание через все notifiers.
+// Ошибка любого из них приводит к повторной отправке через все
+func NewMultiNotifier(notifiers ...reminder.Notifier) reminder.Notifier {
+	if len(notifiers) == 1 {
+		return notifiers[0]
+	}
+	return multiNotifier(notifiers)
+}
+
+func (m multiNotifier) Notify(ctx context.Context, notification *entity.Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
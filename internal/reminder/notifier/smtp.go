@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// smtpTimeout ограничивает отправку одного письма, если у ctx нет более раннего срока
+const smtpTimeout = 30 * time.Second
+
+var errNoRecipient = errors.New("no recipient address")
+
+type SMTPOption struct {
+	// Addr — адрес сервера в формате host:port
+	Addr     string
+	Username string
+	Password string
+	From     string
+	// To — адрес получателя для пользователей, логин которых не является email
+	To string
+}
+
+type smtpNotifier struct {
+	opts SMTPOption
+	host string
+	auth smtp.Auth
+}
+
+// NewSMTPNotifier отправляет напоминание письмом на логин пользователя, если это email, иначе на opts.To
+func NewSMTPNotifier(opts SMTPOption) reminder.Notifier {
+	host, _, _ := net.SplitHostPort(opts.Addr)
+
+	var auth smtp.Auth
+	if opts.Username != "" {
+		auth = smtp.PlainAuth("", opts.Username, opts.Password, host)
+	}
+
+	return &smtpNotifier{
+		opts: opts,
+		host: host,
+		auth: auth,
+	}
+}
+
+func (s *smtpNotifier) Notify(ctx context.Context, notification *entity.Notification) error {
+	to := s.opts.To
+	if strings.Contains(notification.Login, "@") {
+		to = notification.Login
+	}
+	if to == "" {
+		return errNoRecipient
+	}
+
+	return s.send(ctx, to, s.message(to, notification))
+}
+
+// send повторяет smtp.SendMail, но соединение ограничено сроком ctx и smtpTimeout,
+// а отмена ctx закрывает его, чтобы зависший сервер не держал планировщик
+func (s *smtpNotifier) send(ctx context.Context, to string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.opts.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(s.opts.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func (s *smtpNotifier) message(to string, notification *entity.Notification) []byte {
+	var msg strings.Builder
+
+	fmt.Fprintf(&msg, "From: %s\r\n", s.opts.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	// Заголовок задачи может содержать не ASCII, в заголовке письма он кодируется по RFC 2047
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(notification.Header)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "Reminder: "+subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	fmt.Fprintf(&msg, "Task: %s\r\n", notification.Header)
+	fmt.Fprintf(&msg, "Start: %s\r\n", notification.StartDate.Format("02.01.2006 15:04"))
+	if notification.DueDate != nil {
+		fmt.Fprintf(&msg, "Due: %s\r\n", notification.DueDate.Format("02.01.2006 15:04"))
+	}
+
+	return []byte(msg.String())
+}
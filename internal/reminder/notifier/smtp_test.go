@@ -0,0 +1,174 @@
+package notifier
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-todolist-sber/internal/entity"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer принимает одно письмо по протоколу SMTP без TLS и авторизации
+func fakeSMTPServer(t *testing.T) (string, <-chan mail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan mail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var m mail
+
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				m.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				tp.PrintfLine("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				m.to = append(m.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				tp.PrintfLine("250 OK")
+			case command == "DATA":
+				tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				m.data = string(data)
+				tp.PrintfLine("250 OK")
+				mails <- m
+			case command == "QUIT":
+				tp.PrintfLine("221 Bye")
+				return
+			default:
+				tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), mails
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2023, 12, 31, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		login  string
+		to     string
+		wantTo string
+	}{
+		{name: "login is email", login: "user@example.com", to: "fallback@example.com", wantTo: "user@example.com"},
+		{name: "fallback recipient", login: "username", to: "fallback@example.com", wantTo: "fallback@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, mails := fakeSMTPServer(t)
+
+			notifier := NewSMTPNotifier(SMTPOption{Addr: addr, From: "todo@example.com", To: tt.to})
+			err := notifier.Notify(context.Background(), &entity.Notification{
+				ReminderID: 1,
+				TaskID:     2,
+				Login:      tt.login,
+				Header:     "Pay bills",
+				StartDate:  time.Date(2023, 12, 30, 9, 0, 0, 0, time.UTC),
+				DueDate:    &due,
+			})
+			require.NoError(t, err)
+
+			select {
+			case m := <-mails:
+				assert.Equal(t, "todo@example.com", m.from)
+				assert.Equal(t, []string{tt.wantTo}, m.to)
+				assert.Contains(t, m.data, "Subject: Reminder: Pay bills\n")
+				assert.Contains(t, m.data, "Due: 31.12.2023 18:00\n")
+			case <-time.After(5 * time.Second):
+				t.Fatal("mail was not delivered")
+			}
+		})
+	}
+}
+
+func TestSMTPNotifier_Notify_EncodedSubject(t *testing.T) {
+	t.Parallel()
+
+	addr, mails := fakeSMTPServer(t)
+
+	notifier := NewSMTPNotifier(SMTPOption{Addr: addr, From: "todo@example.com", To: "user@example.com"})
+	err := notifier.Notify(context.Background(), &entity.Notification{Login: "username", Header: "Оплатить счета"})
+	require.NoError(t, err)
+
+	select {
+	case m := <-mails:
+		assert.Contains(t, m.data, "Subject: =?utf-8?q?Reminder:_=D0=9E")
+		assert.Contains(t, m.data, "Task: Оплатить счета\n")
+	case <-time.After(5 * time.Second):
+		t.Fatal("mail was not delivered")
+	}
+}
+
+func TestSMTPNotifier_Notify_HungServer(t *testing.T) {
+	t.Parallel()
+
+	// сервер принимает соединение, но не отвечает
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	conns := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conns <- conn
+		}
+	}()
+	t.Cleanup(func() {
+		select {
+		case conn := <-conns:
+			conn.Close()
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	notifier := NewSMTPNotifier(SMTPOption{Addr: listener.Addr().String(), From: "todo@example.com", To: "user@example.com"})
+	start := time.Now()
+	err = notifier.Notify(ctx, &entity.Notification{Login: "username", Header: "Pay bills"})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSMTPNotifier_Notify_NoRecipient(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewSMTPNotifier(SMTPOption{Addr: "127.0.0.1:0", From: "todo@example.com"})
+	err := notifier.Notify(context.Background(), &entity.Notification{Login: "username"})
+	assert.ErrorIs(t, err, errNoRecipient)
+}
@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/apperror"
+	pgxError "go-todolist-sber/internal/apperror/pgx_errors"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder"
+	"go-todolist-sber/pkg/postgres"
+	"time"
+)
+
+const reminderColumns = `id, task_id, id_user, remind_at, coalesce(anchor::text, ''), offset_minutes, attempts, sent_at, created_at`
+
+// fireAt вычисляет время срабатывания напоминания по текущим датам задачи,
+// поэтому перенос даты задачи переносит и напоминание
+const fireAt = `coalesce(r.remind_at, case r.anchor
+					when 'start' then t.start_date
+					when 'due' then t.due_date
+				end - make_interval(mins => r.offset_minutes))`
+
+type reminderRepository struct {
+	*postgres.Postgres
+}
+
+func NewReminderRepository(postgres *postgres.Postgres) reminder.ReminderRepository {
+	return &reminderRepository{
+		postgres,
+	}
+}
+
+func (r *reminderRepository) collectRow(row pgx.Row) (*entity.Reminder, error) {
+	var reminder entity.Reminder
+	err := row.Scan(&reminder.ID, &reminder.TaskID, &reminder.UserID, &reminder.RemindAt, &reminder.Anchor,
+		&reminder.Offset, &reminder.Attempts, &reminder.SentAt, &reminder.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	errCode := pgxError.ErrorCode(err)
+	if errCode == pgxError.ForeignKeyViolation {
+		return nil, apperror.ErrForeignKeyViolation
+	}
+	if errCode == pgxError.UniqueViolation {
+		return nil, apperror.ErrUniqueViolation
+	}
+	return &reminder, err
+}
+
+func (r *reminderRepository) collectRows(rows pgx.Rows) ([]entity.Reminder, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Reminder, error) {
+		reminder, err := r.collectRow(row)
+		if err != nil {
+			return entity.Reminder{}, err
+		}
+		return *reminder, nil
+	})
+}
+
+func (r *reminderRepository) Create(ctx context.Context, reminder *entity.Reminder) (*entity.Reminder, error) {
+	query := `insert into reminder (task_id,id_user,remind_at,anchor,offset_minutes)
+				values ($1,$2,$3,nullif($4, '')::reminder_anchor,$5) returning ` + reminderColumns
+
+	row := r.Pool.QueryRow(ctx, query, reminder.TaskID, reminder.UserID, reminder.RemindAt, string(reminder.Anchor), reminder.Offset)
+	return r.collectRow(row)
+}
+
+func (r *reminderRepository) GetByTaskID(ctx context.Context, taskID int) ([]entity.Reminder, error) {
+	query := `select ` + reminderColumns + ` from reminder where task_id = $1 order by id`
+
+	rows, err := r.Pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.collectRows(rows)
+}
+
+func (r *reminderRepository) GetByID(ctx context.Context, id int) (*entity.Reminder, error) {
+	query := `select ` + reminderColumns + ` from reminder where id = $1`
+
+	row := r.Pool.QueryRow(ctx, query, id)
+	return r.collectRow(row)
+}
+
+func (r *reminderRepository) DeleteByID(ctx context.Context, id int) error {
+	query := `delete from reminder where id = $1`
+
+	_, err := r.Pool.Exec(ctx, query, id)
+	return err
+}
+
+func (r *reminderRepository) ClaimDue(ctx context.Context, now, claimUntil time.Time, maxAttempts, limit int) ([]entity.Notification, error) {
+	query := `with due as (
+				select r.id from reminder r
+					join task t on t.id = r.task_id
+				where r.sent_at is null and not t.done and t.deleted_at is null and r.attempts < $3
+					and (r.claimed_until is null or r.claimed_until <= $1)
+					and ` + fireAt + ` <= $1
+				order by r.id
+				limit $4
+				for update of r skip locked
+			)
+			update reminder r set claimed_until = $2, attempts = r.attempts + 1
+			from due, task t, "user" u
+			where r.id = due.id and t.id = r.task_id and u.id = r.id_user
+			returning r.id, t.id, r.id_user, u.login, t.header, t.start_date, t.due_date, ` + fireAt
+
+	rows, err := r.Pool.Query(ctx, query, now, claimUntil, maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Notification, error) {
+		var n entity.Notification
+		err := row.Scan(&n.ReminderID, &n.TaskID, &n.UserID, &n.Login, &n.Header, &n.StartDate, &n.DueDate, &n.FireAt)
+		return n, err
+	})
+}
+
+func (r *reminderRepository) MarkSent(ctx context.Context, id int, sentAt time.Time) error {
+	query := `update reminder set sent_at = $1, claimed_until = null where id = $2`
+
+	_, err := r.Pool.Exec(ctx, query, sentAt, id)
+	return err
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder"
+	"go-todolist-sber/pkg/logger"
+	"go-todolist-sber/pkg/worker"
+	"time"
+)
+
+const (
+	batchSize = 100
+	// claimTimeout — время, на которое напоминание захватывается для отправки.
+	// Если отправка не удалась или сервер упал, напоминание будет отправлено повторно по его истечении
+	claimTimeout = 5 * time.Minute
+	maxAttempts  = 5
+)
+
+// Scheduler периодически выбирает из базы наступившие напоминания и отправляет их через Notifier.
+// Состояние хранится только в базе, поэтому неотправленные напоминания переживают перезапуск
+type Scheduler struct {
+	reminderRepo reminder.ReminderRepository
+	notifier     reminder.Notifier
+	log          *logger.Logger
+	interval     time.Duration
+	now          func() time.Time
+}
+
+func NewScheduler(reminderRepo reminder.ReminderRepository, notifier reminder.Notifier, log *logger.Logger, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		reminderRepo: reminderRepo,
+		notifier:     notifier,
+		log:          log,
+		interval:     interval,
+		now:          time.Now,
+	}
+}
+
+// Run блокируется до отмены ctx
+func (s *Scheduler) Run(ctx context.Context) {
+	worker.Run(ctx, s.interval, s.Tick)
+}
+
+// Tick отправляет все напоминания, время которых наступило
+func (s *Scheduler) Tick(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := entity.WallClock(s.now())
+
+		notifications, err := s.reminderRepo.ClaimDue(ctx, now, now.Add(claimTimeout), maxAttempts, batchSize)
+		if err != nil {
+			s.log.Error("reminderRepo.ClaimDue: %v", err)
+			return
+		}
+
+		for i := range notifications {
+			s.deliver(ctx, &notifications[i])
+		}
+
+		if len(notifications) < batchSize {
+			return
+		}
+	}
+}
+
+func (s *Scheduler) deliver(ctx context.Context, notification *entity.Notification) {
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		s.log.Error("notifier.Notify: reminder %d: %v", notification.ReminderID, err)
+		return
+	}
+
+	if err := s.reminderRepo.MarkSent(ctx, notification.ReminderID, entity.WallClock(s.now())); err != nil {
+		s.log.Error("reminderRepo.MarkSent: reminder %d: %v", notification.ReminderID, err)
+	}
+}
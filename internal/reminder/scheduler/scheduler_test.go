@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder/mock"
+	"go-todolist-sber/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Tick(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 12, 31, 17, 0, 0, 0, time.UTC)
+	claimUntil := now.Add(claimTimeout)
+
+	type mockBehavior func(r *mock.MockReminderRepository, n *mock.MockNotifier)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+	}{
+		{
+			name: "sent reminders are marked",
+			mockBehavior: func(r *mock.MockReminderRepository, n *mock.MockNotifier) {
+				notifications := []entity.Notification{{ReminderID: 1}, {ReminderID: 2}}
+				r.EXPECT().ClaimDue(gomock.Any(), now, claimUntil, maxAttempts, batchSize).Return(notifications, nil)
+				n.EXPECT().Notify(gomock.Any(), &notifications[0]).Return(nil)
+				r.EXPECT().MarkSent(gomock.Any(), 1, now).Return(nil)
+				n.EXPECT().Notify(gomock.Any(), &notifications[1]).Return(nil)
+				r.EXPECT().MarkSent(gomock.Any(), 2, now).Return(nil)
+			},
+		},
+		{
+			name: "failed reminder is left for retry",
+			mockBehavior: func(r *mock.MockReminderRepository, n *mock.MockNotifier) {
+				notifications := []entity.Notification{{ReminderID: 1}}
+				r.EXPECT().ClaimDue(gomock.Any(), now, claimUntil, maxAttempts, batchSize).Return(notifications, nil)
+				n.EXPECT().Notify(gomock.Any(), &notifications[0]).Return(errors.New("connection refused"))
+			},
+		},
+		{
+			name: "full batch claims next batch",
+			mockBehavior: func(r *mock.MockReminderRepository, n *mock.MockNotifier) {
+				notifications := make([]entity.Notification, batchSize)
+				gomock.InOrder(
+					r.EXPECT().ClaimDue(gomock.Any(), now, claimUntil, maxAttempts, batchSize).Return(notifications, nil),
+					r.EXPECT().ClaimDue(gomock.Any(), now, claimUntil, maxAttempts, batchSize).Return(nil, nil),
+				)
+				n.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil).Times(batchSize)
+				r.EXPECT().MarkSent(gomock.Any(), 0, now).Return(nil).Times(batchSize)
+			},
+		},
+		{
+			name: "claim error",
+			mockBehavior: func(r *mock.MockReminderRepository, n *mock.MockNotifier) {
+				r.EXPECT().ClaimDue(gomock.Any(), now, claimUntil, maxAttempts, batchSize).Return(nil, errors.New("connection refused"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockReminderRepo := mock.NewMockReminderRepository(ctrl)
+			mockNotifier := mock.NewMockNotifier(ctrl)
+			tt.mockBehavior(mockReminderRepo, mockNotifier)
+
+			scheduler := NewScheduler(mockReminderRepo, mockNotifier, logger.New(), time.Minute)
+			scheduler.now = func() time.Time { return now }
+			scheduler.Tick(context.Background())
+		})
+	}
+}
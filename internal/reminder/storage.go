@@ -0,0 +1,19 @@
+package reminder
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+	"time"
+)
+
+//go:generate mockgen -source storage.go -destination mock/reminder_repository_mock.go -package mock
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *entity.Reminder) (*entity.Reminder, error)
+	GetByTaskID(ctx context.Context, taskID int) ([]entity.Reminder, error)
+	GetByID(ctx context.Context, id int) (*entity.Reminder, error)
+	DeleteByID(ctx context.Context, id int) error
+	// ClaimDue захватывает до limit неотправленных напоминаний, время которых наступило к now,
+	// до момента claimUntil, чтобы их не отправили повторно параллельно
+	ClaimDue(ctx context.Context, now, claimUntil time.Time, maxAttempts, limit int) ([]entity.Notification, error)
+	MarkSent(ctx context.Context, id int, sentAt time.Time) error
+}
@@ -0,0 +1,13 @@
+package reminder
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+type ReminderUsecase interface {
+	CreateReminder(ctx context.Context, reminder *entity.Reminder) (*entity.Reminder, error)
+	GetTaskReminders(ctx context.Context, taskID int) ([]entity.Reminder, error)
+	DeleteReminder(ctx context.Context, id int) error
+	IsEqualUserID(ctx context.Context, contextUserID string, reminderID int) (bool, error)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder"
+)
+
+type reminderUsecase struct {
+	reminderRepo reminder.ReminderRepository
+}
+
+func NewReminderUsecase(reminderRepo reminder.ReminderRepository) reminder.ReminderUsecase {
+	return &reminderUsecase{
+		reminderRepo: reminderRepo,
+	}
+}
+
+func (r *reminderUsecase) CreateReminder(ctx context.Context, reminder *entity.Reminder) (*entity.Reminder, error) {
+	if !entity.IsReminderValid(reminder) {
+		return nil, apperror.ErrDataNotValid
+	}
+	if reminder.RemindAt != nil {
+		remindAt := entity.WallClock(*reminder.RemindAt)
+		reminder.RemindAt = &remindAt
+	}
+
+	reminder, err := r.reminderRepo.Create(ctx, reminder)
+	if err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+func (r *reminderUsecase) GetTaskReminders(ctx context.Context, taskID int) ([]entity.Reminder, error) {
+	reminders, err := r.reminderRepo.GetByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (r *reminderUsecase) DeleteReminder(ctx context.Context, id int) error {
+	if err := r.reminderRepo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *reminderUsecase) IsEqualUserID(ctx context.Context, contextUserID string, reminderID int) (bool, error) {
+	data, err := r.reminderRepo.GetByID(ctx, reminderID)
+	if err != nil {
+		return false, err
+	}
+
+	if data.UserID != contextUserID {
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/reminder/mock"
+	"testing"
+	"time"
+)
+
+func TestReminderUsecase_CreateReminder(t *testing.T) {
+	t.Parallel()
+
+	remindAt := time.Date(2023, 12, 31, 17, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(r *mock.MockReminderRepository, reminder *entity.Reminder)
+
+	tests := []struct {
+		name         string
+		reminder     *entity.Reminder
+		mockBehavior mockBehavior
+		want         *entity.Reminder
+		wantErr      error
+	}{
+		{
+			name:     "absolute time",
+			reminder: &entity.Reminder{TaskID: 1, UserID: "uuid", RemindAt: &remindAt},
+			mockBehavior: func(r *mock.MockReminderRepository, reminder *entity.Reminder) {
+				r.EXPECT().Create(context.Background(), gomock.Eq(reminder)).
+					Return(&entity.Reminder{ID: 1, TaskID: 1, UserID: "uuid", RemindAt: &remindAt}, nil)
+			},
+			want:    &entity.Reminder{ID: 1, TaskID: 1, UserID: "uuid", RemindAt: &remindAt},
+			wantErr: nil,
+		},
+		{
+			name:     "offset before due date",
+			reminder: &entity.Reminder{TaskID: 1, UserID: "uuid", Anchor: entity.AnchorDue, Offset: 60},
+			mockBehavior: func(r *mock.MockReminderRepository, reminder *entity.Reminder) {
+				r.EXPECT().Create(context.Background(), gomock.Eq(reminder)).
+					Return(&entity.Reminder{ID: 2, TaskID: 1, UserID: "uuid", Anchor: entity.AnchorDue, Offset: 60}, nil)
+			},
+			want:    &entity.Reminder{ID: 2, TaskID: 1, UserID: "uuid", Anchor: entity.AnchorDue, Offset: 60},
+			wantErr: nil,
+		},
+		{
+			name:         "both absolute time and anchor",
+			reminder:     &entity.Reminder{TaskID: 1, UserID: "uuid", RemindAt: &remindAt, Anchor: entity.AnchorStart},
+			mockBehavior: func(r *mock.MockReminderRepository, reminder *entity.Reminder) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "unknown anchor",
+			reminder:     &entity.Reminder{TaskID: 1, UserID: "uuid", Anchor: "created"},
+			mockBehavior: func(r *mock.MockReminderRepository, reminder *entity.Reminder) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "negative offset",
+			reminder:     &entity.Reminder{TaskID: 1, UserID: "uuid", Anchor: entity.AnchorStart, Offset: -5},
+			mockBehavior: func(r *mock.MockReminderRepository, reminder *entity.Reminder) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockReminderRepo := mock.NewMockReminderRepository(ctrl)
+			tt.mockBehavior(mockReminderRepo, tt.reminder)
+
+			reminderUsecase := NewReminderUsecase(mockReminderRepo)
+			reminder, err := reminderUsecase.CreateReminder(context.Background(), tt.reminder)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, reminder)
+		})
+	}
+}
+
+func TestReminderUsecase_IsEqualUserID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		userID  string
+		want    bool
+		wantErr error
+	}{
+		{name: "owner", userID: "uuid", want: true, wantErr: nil},
+		{name: "another user", userID: "other", want: false, wantErr: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockReminderRepo := mock.NewMockReminderRepository(ctrl)
+			mockReminderRepo.EXPECT().GetByID(context.Background(), 1).Return(&entity.Reminder{ID: 1, UserID: "uuid"}, nil)
+
+			reminderUsecase := NewReminderUsecase(mockReminderRepo)
+			equal, err := reminderUsecase.IsEqualUserID(context.Background(), tt.userID, 1)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, equal)
+		})
+	}
+}
@@ -2,18 +2,42 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/gorilla/sessions"
+	auditRepo "go-todolist-sber/internal/audit/repo"
+	auditUsecase "go-todolist-sber/internal/audit/usecase"
 	"go-todolist-sber/internal/config"
 	"go-todolist-sber/internal/controller/http"
+	idempotencyRepo "go-todolist-sber/internal/idempotency/repo"
+	idempotencyUsecase "go-todolist-sber/internal/idempotency/usecase"
+	jwtRepo "go-todolist-sber/internal/jwtauth/repo"
+	jwtUsecase "go-todolist-sber/internal/jwtauth/usecase"
+	projectRepo "go-todolist-sber/internal/project/repo"
+	projectUsecase "go-todolist-sber/internal/project/usecase"
+	"go-todolist-sber/internal/reminder"
+	reminderNotifier "go-todolist-sber/internal/reminder/notifier"
+	reminderRepo "go-todolist-sber/internal/reminder/repo"
+	reminderScheduler "go-todolist-sber/internal/reminder/scheduler"
+	reminderUsecase "go-todolist-sber/internal/reminder/usecase"
 	sessionRepo "go-todolist-sber/internal/session/repo"
 	sessionUsecase "go-todolist-sber/internal/session/usecase"
+	statusRepo "go-todolist-sber/internal/status/repo"
+	statusUsecase "go-todolist-sber/internal/status/usecase"
+	tagRepo "go-todolist-sber/internal/tag/repo"
+	tagUsecase "go-todolist-sber/internal/tag/usecase"
 	taskRepo "go-todolist-sber/internal/task/repo"
 	taskUsecase "go-todolist-sber/internal/task/usecase"
+	tokenRepo "go-todolist-sber/internal/token/repo"
+	tokenUsecase "go-todolist-sber/internal/token/usecase"
 	userRepo "go-todolist-sber/internal/user/repo"
 	userUsecase "go-todolist-sber/internal/user/usecase"
+	"go-todolist-sber/pkg/jwt"
 	"go-todolist-sber/pkg/logger"
 	"go-todolist-sber/pkg/postgres"
+	"go-todolist-sber/pkg/worker"
+	"net/netip"
 )
 
 func Run(log *logger.Logger, cfg *config.Config) error {
@@ -25,22 +49,82 @@ func Run(log *logger.Logger, cfg *config.Config) error {
 	defer psql.Close()
 
 	taskRepo := taskRepo.NewTaskRepository(psql)
+	tagRepo := tagRepo.NewTagRepository(psql)
+	projectRepo := projectRepo.NewProjectRepository(psql)
+	reminderRepo := reminderRepo.NewReminderRepository(psql)
 	userRepo := userRepo.NewUserRepository(psql)
 	sessionRepo := sessionRepo.NewSessionRepository(psql)
+	tokenRepo := tokenRepo.NewTokenRepository(psql)
+	statusRepo := statusRepo.NewStatusRepository(psql)
+	auditRepo := auditRepo.NewAuditRepository(psql)
+	idempotencyRepo := idempotencyRepo.NewIdempotencyRepository(psql)
 
-	taskUsecase := taskUsecase.NewTaskUsecase(taskRepo)
+	statusUsecase := statusUsecase.NewStatusUsecase(statusRepo)
+	taskUsecase := taskUsecase.NewTaskUsecase(taskRepo, auditRepo, statusUsecase, psql)
+	tagUsecase := tagUsecase.NewTagUsecase(tagRepo)
+	projectUsecase := projectUsecase.NewProjectUsecase(projectRepo)
+	reminderUsecase := reminderUsecase.NewReminderUsecase(reminderRepo)
 	userUsecase := userUsecase.NewUserUsecase(userRepo, cfg.Salt)
-	sessionUsecase := sessionUsecase.NewSessionUsecase(sessionRepo)
+	sessionUsecase := sessionUsecase.NewSessionUsecase(sessionRepo, cfg.Session.TTL, cfg.Session.MaxLifetime)
+	tokenUsecase := tokenUsecase.NewTokenUsecase(tokenRepo)
+	auditUsecase := auditUsecase.NewAuditUsecase(auditRepo)
+	idempotencyUsecase := idempotencyUsecase.NewIdempotencyUsecase(idempotencyRepo, cfg.Idempotency.TTL)
+
+	notifier, err := newNotifier(log, cfg.Reminder)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheduler := reminderScheduler.NewScheduler(reminderRepo, notifier, log, cfg.Reminder.Interval)
+	go scheduler.Run(ctx)
+
+	trashPurger := worker.NewPurger("tasks from trash", taskRepo.Purge, log, cfg.Trash.PurgeInterval, cfg.Trash.Retention)
+	go trashPurger.Run(ctx)
+
+	keyPurger := worker.NewPurger("expired idempotency keys", idempotencyRepo.Purge, log, cfg.Idempotency.PurgeInterval, 0)
+	go keyPurger.Run(ctx)
+
+	sessionPurger := worker.NewPurger("expired sessions", sessionRepo.Purge, log, cfg.Session.PurgeInterval, 0)
+	go sessionPurger.Run(ctx)
 
 	var store = sessions.NewCookieStore([]byte("secret-key"))
 	store.Options = &sessions.Options{
 		Path:     "/",
-		MaxAge:   3600,
+		MaxAge:   int(cfg.Session.MaxLifetime.Seconds()),
 		HttpOnly: true,
 	}
 
-	server := http.NewServer(log, http.Services{Task: taskUsecase, User: userUsecase, Session: sessionUsecase}, http.ServerOption{
-		Addr: fmt.Sprintf(":%s", cfg.HTTTPServer.Port),
+	services := http.Services{Task: taskUsecase, Tag: tagUsecase, Project: projectUsecase, Reminder: reminderUsecase, Status: statusUsecase, Audit: auditUsecase, Idempotency: idempotencyUsecase, User: userUsecase, Session: sessionUsecase, Token: tokenUsecase}
+
+	switch cfg.Auth.Mode {
+	case config.AuthModeSession:
+	case config.AuthModeJWT:
+		keys, err := newKeySet(log, cfg.Auth.JWT)
+		if err != nil {
+			return err
+		}
+
+		refreshTokenRepo := jwtRepo.NewRefreshTokenRepository(psql)
+		services.JWT = jwtUsecase.NewJWTUsecase(refreshTokenRepo, keys, cfg.Auth.JWT.Issuer, cfg.Auth.JWT.AccessTTL, cfg.Auth.JWT.RefreshTTL,
+			cfg.Auth.JWT.MaxLifetime)
+
+		refreshTokenPurger := worker.NewPurger("expired refresh tokens", refreshTokenRepo.Purge, log, cfg.Auth.JWT.PurgeInterval, 0)
+		go refreshTokenPurger.Run(ctx)
+	default:
+		return fmt.Errorf("unknown AUTH_MODE %q, expected %s or %s", cfg.Auth.Mode, config.AuthModeSession, config.AuthModeJWT)
+	}
+
+	trustedProxies, err := parseTrustedProxies(cfg.HTTTPServer.TrustedProxies)
+	if err != nil {
+		return err
+	}
+
+	server := http.NewServer(log, services, http.ServerOption{
+		Addr:           fmt.Sprintf(":%s", cfg.HTTTPServer.Port),
+		TrustedProxies: trustedProxies,
 	}, store)
 
 	log.Info("Starting http server on %s: %s:%s", cfg.HTTTPServer.TypeServer, cfg.HTTTPServer.Hostname, cfg.HTTTPServer.Port)
@@ -50,3 +134,73 @@ func Run(log *logger.Logger, cfg *config.Config) error {
 
 	return nil
 }
+
+// newKeySet собирает ключи подписи JWT. Без JWT_KEYS создается временный ключ,
+// и выданные токены перестают приниматься после перезапуска
+func newKeySet(log *logger.Logger, cfg config.JWT) (*jwt.KeySet, error) {
+	if len(cfg.Keys) == 0 {
+		log.Info("JWT_KEYS is empty, tokens are signed with a temporary key")
+		key, err := jwt.GenerateKey(uuid.New().String())
+		if err != nil {
+			return nil, err
+		}
+		return jwt.NewKeySet(key)
+	}
+
+	keys := make([]jwt.Key, 0, len(cfg.Keys))
+	for _, spec := range cfg.Keys {
+		key, err := jwt.ParseKey(spec)
+		if err != nil {
+			return nil, fmt.Errorf("JWT_KEYS: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return jwt.NewKeySet(keys...)
+}
+
+// parseTrustedProxies разбирает адреса и сети доверенных прокси, одиночный адрес считается сетью из одного адреса
+func parseTrustedProxies(specs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(specs))
+	for _, spec := range specs {
+		if addr, err := netip.ParseAddr(spec); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(spec)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP_TRUSTED_PROXIES: %w", err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+func newNotifier(log *logger.Logger, cfg config.Reminder) (reminder.Notifier, error) {
+	notifiers := make([]reminder.Notifier, 0, len(cfg.Notifiers))
+	for _, name := range cfg.Notifiers {
+		switch name {
+		case "log":
+			notifiers = append(notifiers, reminderNotifier.NewLogNotifier(log))
+		case "webhook":
+			if cfg.WebhookURL == "" {
+				return nil, errors.New("webhook notifier requires REMINDER_WEBHOOK_URL")
+			}
+			notifiers = append(notifiers, reminderNotifier.NewWebhookNotifier(cfg.WebhookURL))
+		case "smtp":
+			if cfg.SMTP.Addr == "" || cfg.SMTP.From == "" {
+				return nil, errors.New("smtp notifier requires SMTP_ADDR and SMTP_FROM")
+			}
+			notifiers = append(notifiers, reminderNotifier.NewSMTPNotifier(reminderNotifier.SMTPOption{
+				Addr:     cfg.SMTP.Addr,
+				Username: cfg.SMTP.Username,
+				Password: cfg.SMTP.Password,
+				From:     cfg.SMTP.From,
+				To:       cfg.SMTP.To,
+			}))
+		default:
+			return nil, fmt.Errorf("unknown reminder notifier %q", name)
+		}
+	}
+
+	return reminderNotifier.NewMultiNotifier(notifiers...), nil
+}
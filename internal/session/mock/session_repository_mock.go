@@ -8,6 +8,7 @@ import (
 	context "context"
 	entity "go-todolist-sber/internal/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -50,6 +51,49 @@ func (mr *MockSessionRepositoryMockRecorder) Create(ctx, session interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionRepository)(nil).Create), ctx, session)
 }
 
+// Delete mocks base method.
+func (m *MockSessionRepository) Delete(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSessionRepositoryMockRecorder) Delete(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionRepository)(nil).Delete), ctx, id, userID)
+}
+
+// DeleteByToken mocks base method.
+func (m *MockSessionRepository) DeleteByToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByToken indicates an expected call of DeleteByToken.
+func (mr *MockSessionRepositoryMockRecorder) DeleteByToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByToken", reflect.TypeOf((*MockSessionRepository)(nil).DeleteByToken), ctx, token)
+}
+
+// DeleteOthers mocks base method.
+func (m *MockSessionRepository) DeleteOthers(ctx context.Context, userID, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOthers", ctx, userID, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOthers indicates an expected call of DeleteOthers.
+func (mr *MockSessionRepositoryMockRecorder) DeleteOthers(ctx, userID, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOthers", reflect.TypeOf((*MockSessionRepository)(nil).DeleteOthers), ctx, userID, token)
+}
+
 // GetByToken mocks base method.
 func (m *MockSessionRepository) GetByToken(ctx context.Context, token string) (*entity.Session, error) {
 	m.ctrl.T.Helper()
@@ -65,17 +109,46 @@ func (mr *MockSessionRepositoryMockRecorder) GetByToken(ctx, token interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockSessionRepository)(nil).GetByToken), ctx, token)
 }
 
-// Update mocks base method.
-func (m *MockSessionRepository) Update(ctx context.Context, session *entity.Session) (*entity.Session, error) {
+// GetByUserID mocks base method.
+func (m *MockSessionRepository) GetByUserID(ctx context.Context, userID string) ([]entity.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, session)
-	ret0, _ := ret[0].(*entity.Session)
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].([]entity.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockSessionRepositoryMockRecorder) Update(ctx, session interface{}) *gomock.Call {
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockSessionRepositoryMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockSessionRepository)(nil).GetByUserID), ctx, userID)
+}
+
+// Purge mocks base method.
+func (m *MockSessionRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockSessionRepositoryMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockSessionRepository)(nil).Purge), ctx, before)
+}
+
+// Touch mocks base method.
+func (m *MockSessionRepository) Touch(ctx context.Context, token string, lastSeenAt, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, token, lastSeenAt, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockSessionRepositoryMockRecorder) Touch(ctx, token, lastSeenAt, expiresAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSessionRepository)(nil).Update), ctx, session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockSessionRepository)(nil).Touch), ctx, token, lastSeenAt, expiresAt)
 }
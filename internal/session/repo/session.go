@@ -8,8 +8,11 @@ import (
 	"go-todolist-sber/internal/entity"
 	"go-todolist-sber/internal/session"
 	"go-todolist-sber/pkg/postgres"
+	"time"
 )
 
+const sessionColumns = `id, token, user_id, expires_at, user_agent, ip, created_at, last_seen_at`
+
 type sessionRepo struct {
 	*postgres.Postgres
 }
@@ -22,7 +25,8 @@ func NewSessionRepository(postgres *postgres.Postgres) session.SessionRepository
 
 func (s *sessionRepo) collectRow(row pgx.Row) (*entity.Session, error) {
 	var session entity.Session
-	err := row.Scan(&session.Token, &session.UserID, &session.ExpiresAt)
+	err := row.Scan(&session.ID, &session.Token, &session.UserID, &session.ExpiresAt, &session.UserAgent, &session.IP,
+		&session.CreatedAt, &session.LastSeenAt)
 	if err == pgx.ErrNoRows {
 		return nil, apperror.ErrNoRows
 	}
@@ -40,24 +44,31 @@ func (s *sessionRepo) collectRow(row pgx.Row) (*entity.Session, error) {
 func (s *sessionRepo) collectRows(rows pgx.Rows) ([]entity.Session, error) {
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Session, error) {
 		session, err := s.collectRow(row)
-		return *session, err
+		if err != nil {
+			return entity.Session{}, err
+		}
+		return *session, nil
 	})
 }
 
 func (s *sessionRepo) Create(ctx context.Context, session *entity.Session) (*entity.Session, error) {
-	query := `insert into session (token,user_id,expires_at) values ($1,$2,$3) returning *`
+	query := `insert into session (token, user_id, expires_at, user_agent, ip, created_at, last_seen_at)
+				values ($1, $2, $3, $4, $5, $6, $6) returning ` + sessionColumns
 
-	row := s.Pool.QueryRow(ctx, query, session.Token, session.UserID, session.ExpiresAt)
+	row := s.Pool.QueryRow(ctx, query, session.Token, session.UserID, session.ExpiresAt, session.UserAgent, session.IP,
+		session.CreatedAt)
 	return s.collectRow(row)
 }
 
 func (s *sessionRepo) GetByToken(ctx context.Context, token string) (*entity.Session, error) {
-	query := `select s.token, s.user_id, s.expires_at, u.role from session s
+	query := `select s.id, s.token, s.user_id, s.expires_at, s.user_agent, s.ip, s.created_at, s.last_seen_at, u.role
+				from session s
 				join "user" u on s.user_id = u.id
 				where token = $1`
 	var session entity.Session
 
-	err := s.Pool.QueryRow(ctx, query, token).Scan(&session.Token, &session.UserID, &session.ExpiresAt, &session.Role)
+	err := s.Pool.QueryRow(ctx, query, token).Scan(&session.ID, &session.Token, &session.UserID, &session.ExpiresAt,
+		&session.UserAgent, &session.IP, &session.CreatedAt, &session.LastSeenAt, &session.Role)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, apperror.ErrNoRows
@@ -75,9 +86,65 @@ func (s *sessionRepo) GetByToken(ctx context.Context, token string) (*entity.Ses
 	return &session, nil
 }
 
-func (s *sessionRepo) Update(ctx context.Context, session *entity.Session) (*entity.Session, error) {
-	query := `update session set token = $1, expires_at = $2 where user_id = $3 returning *`
+// GetByUserID возвращает сессии пользователя, последние использованные первыми
+func (s *sessionRepo) GetByUserID(ctx context.Context, userID string) ([]entity.Session, error) {
+	query := `select ` + sessionColumns + ` from session where user_id = $1 order by last_seen_at desc, created_at desc`
 
-	row := s.Pool.QueryRow(ctx, query, session.Token, session.ExpiresAt, session.UserID)
-	return s.collectRow(row)
+	rows, err := s.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.collectRows(rows)
+}
+
+// Touch отмечает время последнего запроса с сессией и продлевает ее до expiresAt
+func (s *sessionRepo) Touch(ctx context.Context, token string, lastSeenAt time.Time, expiresAt time.Time) error {
+	query := `update session set last_seen_at = $1, expires_at = $2 where token = $3`
+
+	_, err := s.Pool.Exec(ctx, query, lastSeenAt, expiresAt, token)
+	return err
+}
+
+// Delete удаляет сессию id пользователя userID
+func (s *sessionRepo) Delete(ctx context.Context, id string, userID string) error {
+	query := `delete from session where id = $1 and user_id = $2`
+
+	tag, err := s.Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteByToken удаляет сессию с токеном token, отсутствие сессии ошибкой не считается
+func (s *sessionRepo) DeleteByToken(ctx context.Context, token string) error {
+	query := `delete from session where token = $1`
+
+	_, err := s.Pool.Exec(ctx, query, token)
+	return err
+}
+
+// DeleteOthers удаляет все сессии пользователя, кроме сессии с токеном token, и возвращает их количество
+func (s *sessionRepo) DeleteOthers(ctx context.Context, userID string, token string) (int64, error) {
+	query := `delete from session where user_id = $1 and token <> $2`
+
+	tag, err := s.Pool.Exec(ctx, query, userID, token)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Purge удаляет сессии, истекшие раньше before, и возвращает их количество
+func (s *sessionRepo) Purge(ctx context.Context, before time.Time) (int64, error) {
+	query := `delete from session where expires_at < $1`
+
+	tag, err := s.Pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
 }
@@ -3,10 +3,16 @@ package session
 import (
 	"context"
 	"go-todolist-sber/internal/entity"
+	"time"
 )
 
 type SessionRepository interface {
 	Create(ctx context.Context, session *entity.Session) (*entity.Session, error)
 	GetByToken(ctx context.Context, token string) (*entity.Session, error)
-	Update(ctx context.Context, session *entity.Session) (*entity.Session, error)
+	GetByUserID(ctx context.Context, userID string) ([]entity.Session, error)
+	Touch(ctx context.Context, token string, lastSeenAt time.Time, expiresAt time.Time) error
+	Delete(ctx context.Context, id string, userID string) error
+	DeleteByToken(ctx context.Context, token string) error
+	DeleteOthers(ctx context.Context, userID string, token string) (int64, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
 }
@@ -6,7 +6,10 @@ import (
 )
 
 type SessionUsecase interface {
-	CreateToken(ctx context.Context, token string, userID string) (*entity.Session, error)
-	UpdateToken(ctx context.Context, token string, userID string) (*entity.Session, error)
+	CreateToken(ctx context.Context, token string, userID string, device entity.Device, previous string) (*entity.Session, error)
 	GetToken(ctx context.Context, token string) (*entity.Session, error)
+	GetSessions(ctx context.Context, userID string, token string) ([]entity.Session, error)
+	DeleteSession(ctx context.Context, id string, userID string) error
+	DeleteToken(ctx context.Context, token string) error
+	DeleteOtherSessions(ctx context.Context, userID string, token string) (int64, error)
 }
@@ -2,26 +2,58 @@ package usecase
 
 import (
 	"context"
+	"go-todolist-sber/internal/apperror"
 	"go-todolist-sber/internal/entity"
 	"go-todolist-sber/internal/session"
 	"time"
 )
 
+// lastSeenPrecision — время последнего запроса и срок сессии обновляются не чаще,
+// чтобы не писать в базу на каждый запрос
+const lastSeenPrecision = time.Minute
+
 type sessionUsecase struct {
 	sessionRepo session.SessionRepository
+	// ttl — время жизни сессии без запросов, каждый запрос продлевает сессию на ttl,
+	// но не дальше maxLifetime от входа
+	ttl         time.Duration
+	maxLifetime time.Duration
+	now         func() time.Time
 }
 
-func NewSessionUsecase(sessionRepo session.SessionRepository) session.SessionUsecase {
+func NewSessionUsecase(sessionRepo session.SessionRepository, ttl, maxLifetime time.Duration) session.SessionUsecase {
 	return &sessionUsecase{
 		sessionRepo: sessionRepo,
+		ttl:         ttl,
+		maxLifetime: maxLifetime,
+		now:         time.Now,
+	}
+}
+
+// expiresAt возвращает срок сессии, созданной в createdAt, при запросе в now
+func (s *sessionUsecase) expiresAt(createdAt, now time.Time) time.Time {
+	expiresAt := now.Add(s.ttl)
+	if limit := createdAt.Add(s.maxLifetime); expiresAt.After(limit) {
+		return limit
 	}
+	return expiresAt
 }
 
-func (s *sessionUsecase) CreateToken(ctx context.Context, token string, userID string) (*entity.Session, error) {
+// CreateToken открывает новую сессию пользователя на устройстве device. previous — токен из cookie запроса:
+// новая cookie заменит его, поэтому прежняя сессия этого браузера отзывается. Сессии на других устройствах не меняются
+func (s *sessionUsecase) CreateToken(ctx context.Context, token string, userID string, device entity.Device, previous string) (*entity.Session, error) {
+	if err := s.DeleteToken(ctx, previous); err != nil {
+		return nil, err
+	}
+
+	now := entity.WallClock(s.now())
 	session := &entity.Session{
-		ExpiresAt: time.Now().Add(1 * time.Hour),
+		ExpiresAt: s.expiresAt(now, now),
 		Token:     token,
 		UserID:    userID,
+		UserAgent: device.UserAgent,
+		IP:        device.IP,
+		CreatedAt: now,
 	}
 
 	data, err := s.sessionRepo.Create(ctx, session)
@@ -32,26 +64,57 @@ func (s *sessionUsecase) CreateToken(ctx context.Context, token string, userID s
 	return data, nil
 }
 
+// GetToken возвращает действующую сессию и продлевает ее, истекшая сессия дает ErrSessionExpired
 func (s *sessionUsecase) GetToken(ctx context.Context, token string) (*entity.Session, error) {
 	session, err := s.sessionRepo.GetByToken(ctx, token)
 	if err != nil {
 		return nil, err
 	}
 
-	return session, nil
-}
+	now := entity.WallClock(s.now())
+	// срок входа проверяется отдельно на случай, если максимальное время жизни сократили в настройках
+	if !now.Before(session.ExpiresAt) || !now.Before(session.CreatedAt.Add(s.maxLifetime)) {
+		return nil, apperror.ErrSessionExpired
+	}
 
-func (s *sessionUsecase) UpdateToken(ctx context.Context, token string, userID string) (*entity.Session, error) {
-	session := &entity.Session{
-		Token:     token,
-		ExpiresAt: time.Now().Add(1 * time.Hour),
-		UserID:    userID,
+	if now.Sub(session.LastSeenAt) >= lastSeenPrecision {
+		expiresAt := s.expiresAt(session.CreatedAt, now)
+		if err := s.sessionRepo.Touch(ctx, token, now, expiresAt); err != nil {
+			return nil, err
+		}
+		session.LastSeenAt, session.ExpiresAt = now, expiresAt
 	}
 
-	data, err := s.sessionRepo.Update(ctx, session)
+	return session, nil
+}
+
+// GetSessions возвращает сессии пользователя, сессия с токеном token отмечается как текущая
+func (s *sessionUsecase) GetSessions(ctx context.Context, userID string, token string) ([]entity.Session, error) {
+	sessions, err := s.sessionRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return data, nil
+	for i := range sessions {
+		sessions[i].Current = sessions[i].Token == token
+	}
+	return sessions, nil
+}
+
+// DeleteSession завершает сессию id пользователя, чужая сессия неотличима от несуществующей
+func (s *sessionUsecase) DeleteSession(ctx context.Context, id string, userID string) error {
+	return s.sessionRepo.Delete(ctx, id, userID)
+}
+
+// DeleteToken отзывает сессию с токеном token, после этого токен не принимается даже из сохраненной cookie
+func (s *sessionUsecase) DeleteToken(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+	return s.sessionRepo.DeleteByToken(ctx, token)
+}
+
+// DeleteOtherSessions завершает все сессии пользователя, кроме сессии с токеном token
+func (s *sessionUsecase) DeleteOtherSessions(ctx context.Context, userID string, token string) (int64, error) {
+	return s.sessionRepo.DeleteOthers(ctx, userID, token)
 }
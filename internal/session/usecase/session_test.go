@@ -13,12 +13,16 @@ import (
 )
 
 func TestSessionUsecase_CreateToken(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
 	type mockBehavior func(r *mock.MockSessionRepository, session *entity.Session)
 
 	type args struct {
-		session *entity.Session
-		userID  string
-		token   string
+		session  *entity.Session
+		userID   string
+		token    string
+		device   entity.Device
+		previous string
 	}
 
 	tests := []struct {
@@ -33,16 +37,40 @@ func TestSessionUsecase_CreateToken(t *testing.T) {
 			args: args{session: &entity.Session{
 				UserID:    "uuid",
 				Token:     "token",
-				ExpiresAt: time.Now().Add(1 * time.Hour),
+				ExpiresAt: now.Add(1 * time.Hour),
+				UserAgent: "Mozilla/5.0",
+				IP:        "10.0.0.1",
+				CreatedAt: now,
 			},
 				token:  "token",
-				userID: "uuid"},
+				userID: "uuid",
+				device: entity.Device{UserAgent: "Mozilla/5.0", IP: "10.0.0.1"}},
 			mockBehavior: func(r *mock.MockSessionRepository, session *entity.Session) {
 				r.EXPECT().Create(context.Background(), session).Return(&entity.Session{}, nil)
 			},
 			want:    &entity.Session{},
 			wantErr: nil,
 		},
+		{
+			name: "login from the same browser revokes previous session",
+			args: args{session: &entity.Session{
+				UserID:    "uuid",
+				Token:     "token",
+				ExpiresAt: now.Add(1 * time.Hour),
+				CreatedAt: now,
+			},
+				token:    "token",
+				userID:   "uuid",
+				previous: "previous-token"},
+			mockBehavior: func(r *mock.MockSessionRepository, session *entity.Session) {
+				gomock.InOrder(
+					r.EXPECT().DeleteByToken(context.Background(), "previous-token").Return(nil),
+					r.EXPECT().Create(context.Background(), session).Return(&entity.Session{}, nil),
+				)
+			},
+			want:    &entity.Session{},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -51,9 +79,9 @@ func TestSessionUsecase_CreateToken(t *testing.T) {
 			mockSessionRepo := mock.NewMockSessionRepository(ctrl)
 			tt.mockBehavior(mockSessionRepo, tt.args.session)
 
-			sessionUsecase := NewSessionUsecase(mockSessionRepo)
+			sessionUsecase := &sessionUsecase{sessionRepo: mockSessionRepo, ttl: time.Hour, maxLifetime: 24 * time.Hour, now: func() time.Time { return now }}
 
-			sess, err := sessionUsecase.CreateToken(context.Background(), tt.args.token, tt.args.userID)
+			sess, err := sessionUsecase.CreateToken(context.Background(), tt.args.token, tt.args.userID, tt.args.device, tt.args.previous)
 			assert.Equal(t, tt.wantErr, err)
 			assert.Equal(t, tt.want, sess)
 		})
@@ -61,6 +89,8 @@ func TestSessionUsecase_CreateToken(t *testing.T) {
 }
 
 func TestSessionUsecase_GetToken(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
 	type mockBehavior func(r *mock.MockSessionRepository, token string)
 
 	type args struct {
@@ -80,11 +110,62 @@ func TestSessionUsecase_GetToken(t *testing.T) {
 				token: uuid.New().String(),
 			},
 			mockBehavior: func(r *mock.MockSessionRepository, token string) {
-				r.EXPECT().GetByToken(context.Background(), token).Return(&entity.Session{}, nil)
+				r.EXPECT().GetByToken(context.Background(), token).
+					Return(&entity.Session{CreatedAt: now.Add(-time.Hour), LastSeenAt: now.Add(-time.Second), ExpiresAt: now.Add(time.Hour)}, nil)
 			},
-			want:    &entity.Session{},
+			want:    &entity.Session{CreatedAt: now.Add(-time.Hour), LastSeenAt: now.Add(-time.Second), ExpiresAt: now.Add(time.Hour)},
 			wantErr: nil,
 		},
+		{
+			name: "sliding renewal",
+			args: args{
+				token: uuid.New().String(),
+			},
+			mockBehavior: func(r *mock.MockSessionRepository, token string) {
+				r.EXPECT().GetByToken(context.Background(), token).
+					Return(&entity.Session{CreatedAt: now.Add(-time.Hour), LastSeenAt: now.Add(-30 * time.Minute), ExpiresAt: now.Add(30 * time.Minute)}, nil)
+				r.EXPECT().Touch(context.Background(), token, now, now.Add(time.Hour)).Return(nil)
+			},
+			want:    &entity.Session{CreatedAt: now.Add(-time.Hour), LastSeenAt: now, ExpiresAt: now.Add(time.Hour)},
+			wantErr: nil,
+		},
+		{
+			name: "renewal is limited by max lifetime",
+			args: args{
+				token: uuid.New().String(),
+			},
+			mockBehavior: func(r *mock.MockSessionRepository, token string) {
+				r.EXPECT().GetByToken(context.Background(), token).
+					Return(&entity.Session{CreatedAt: now.Add(-23*time.Hour - 30*time.Minute), LastSeenAt: now.Add(-10 * time.Minute), ExpiresAt: now.Add(50 * time.Minute)}, nil)
+				r.EXPECT().Touch(context.Background(), token, now, now.Add(30*time.Minute)).Return(nil)
+			},
+			want:    &entity.Session{CreatedAt: now.Add(-23*time.Hour - 30*time.Minute), LastSeenAt: now, ExpiresAt: now.Add(30 * time.Minute)},
+			wantErr: nil,
+		},
+		{
+			name: "expired",
+			args: args{
+				token: uuid.New().String(),
+			},
+			mockBehavior: func(r *mock.MockSessionRepository, token string) {
+				r.EXPECT().GetByToken(context.Background(), token).
+					Return(&entity.Session{CreatedAt: now.Add(-2 * time.Hour), LastSeenAt: now.Add(-time.Hour), ExpiresAt: now}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrSessionExpired,
+		},
+		{
+			name: "max lifetime exceeded",
+			args: args{
+				token: uuid.New().String(),
+			},
+			mockBehavior: func(r *mock.MockSessionRepository, token string) {
+				r.EXPECT().GetByToken(context.Background(), token).
+					Return(&entity.Session{CreatedAt: now.Add(-25 * time.Hour), LastSeenAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrSessionExpired,
+		},
 		{
 			name: "not found",
 			args: args{
@@ -104,7 +185,7 @@ func TestSessionUsecase_GetToken(t *testing.T) {
 			mockSessionRepo := mock.NewMockSessionRepository(ctrl)
 			tt.mockBehavior(mockSessionRepo, tt.args.token)
 
-			sessionUsecase := NewSessionUsecase(mockSessionRepo)
+			sessionUsecase := &sessionUsecase{sessionRepo: mockSessionRepo, ttl: time.Hour, maxLifetime: 24 * time.Hour, now: func() time.Time { return now }}
 
 			sess, err := sessionUsecase.GetToken(context.Background(), tt.args.token)
 			assert.Equal(t, tt.wantErr, err)
@@ -113,50 +194,59 @@ func TestSessionUsecase_GetToken(t *testing.T) {
 	}
 }
 
-func TestSessionUsecase_UpdateToken(t *testing.T) {
-	type mockBehavior func(r *mock.MockSessionRepository, session *entity.Session)
+func TestSessionUsecase_GetSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSessionRepo := mock.NewMockSessionRepository(ctrl)
+	mockSessionRepo.EXPECT().GetByUserID(context.Background(), "uuid").Return([]entity.Session{
+		{ID: "phone", Token: "token-phone", UserID: "uuid"},
+		{ID: "laptop", Token: "token-laptop", UserID: "uuid"},
+	}, nil)
+
+	sessionUsecase := NewSessionUsecase(mockSessionRepo, time.Hour, 24*time.Hour)
+
+	sessions, err := sessionUsecase.GetSessions(context.Background(), "uuid", "token-laptop")
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Session{
+		{ID: "phone", Token: "token-phone", UserID: "uuid"},
+		{ID: "laptop", Token: "token-laptop", UserID: "uuid", Current: true},
+	}, sessions)
+}
 
-	type args struct {
-		session *entity.Session
-		userID  string
-		token   string
-	}
+func TestSessionUsecase_DeleteToken(t *testing.T) {
+	type mockBehavior func(r *mock.MockSessionRepository, token string)
 
 	tests := []struct {
 		name         string
-		args         args
+		token        string
 		mockBehavior mockBehavior
-		want         *entity.Session
 		wantErr      error
 	}{
 		{
-			name: "ok",
-			args: args{session: &entity.Session{
-				UserID:    "uuid",
-				Token:     "token",
-				ExpiresAt: time.Now().Add(1 * time.Hour),
-			},
-				token:  "token",
-				userID: "uuid"},
-			mockBehavior: func(r *mock.MockSessionRepository, session *entity.Session) {
-				r.EXPECT().Update(context.Background(), session).Return(&entity.Session{}, nil)
+			name:  "ok",
+			token: uuid.New().String(),
+			mockBehavior: func(r *mock.MockSessionRepository, token string) {
+				r.EXPECT().DeleteByToken(context.Background(), token).Return(nil)
 			},
-			want:    &entity.Session{},
 			wantErr: nil,
 		},
+		{
+			name:         "without session",
+			token:        "",
+			mockBehavior: func(r *mock.MockSessionRepository, token string) {},
+			wantErr:      nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockSessionRepo := mock.NewMockSessionRepository(ctrl)
-			tt.mockBehavior(mockSessionRepo, tt.args.session)
+			tt.mockBehavior(mockSessionRepo, tt.token)
 
-			sessionUsecase := NewSessionUsecase(mockSessionRepo)
+			sessionUsecase := NewSessionUsecase(mockSessionRepo, time.Hour, 24*time.Hour)
 
-			sess, err := sessionUsecase.UpdateToken(context.Background(), tt.args.token, tt.args.userID)
+			err := sessionUsecase.DeleteToken(context.Background(), tt.token)
 			assert.Equal(t, tt.wantErr, err)
-			assert.Equal(t, tt.want, sess)
 		})
 	}
 }
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: storage.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	entity "go-todolist-sber/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatusRepository is a mock of StatusRepository interface.
+type MockStatusRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusRepositoryMockRecorder
+}
+
+// MockStatusRepositoryMockRecorder is the mock recorder for MockStatusRepository.
+type MockStatusRepositoryMockRecorder struct {
+	mock *MockStatusRepository
+}
+
+// NewMockStatusRepository creates a new mock instance.
+func NewMockStatusRepository(ctrl *gomock.Controller) *MockStatusRepository {
+	mock := &MockStatusRepository{ctrl: ctrl}
+	mock.recorder = &MockStatusRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusRepository) EXPECT() *MockStatusRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatusRepository) Create(ctx context.Context, status *entity.Status) (*entity.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, status)
+	ret0, _ := ret[0].(*entity.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusRepositoryMockRecorder) Create(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatusRepository)(nil).Create), ctx, status)
+}
+
+// CreateDefaults mocks base method.
+func (m *MockStatusRepository) CreateDefaults(ctx context.Context, userID string, statuses []entity.Status) ([]entity.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDefaults", ctx, userID, statuses)
+	ret0, _ := ret[0].([]entity.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDefaults indicates an expected call of CreateDefaults.
+func (mr *MockStatusRepositoryMockRecorder) CreateDefaults(ctx, userID, statuses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDefaults", reflect.TypeOf((*MockStatusRepository)(nil).CreateDefaults), ctx, userID, statuses)
+}
+
+// DeleteByID mocks base method.
+func (m *MockStatusRepository) DeleteByID(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockStatusRepositoryMockRecorder) DeleteByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockStatusRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockStatusRepository) GetByID(ctx context.Context, id int) (*entity.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockStatusRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockStatusRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByScope mocks base method.
+func (m *MockStatusRepository) GetByScope(ctx context.Context, userID string, projectID *int) ([]entity.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByScope", ctx, userID, projectID)
+	ret0, _ := ret[0].([]entity.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByScope indicates an expected call of GetByScope.
+func (mr *MockStatusRepositoryMockRecorder) GetByScope(ctx, userID, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByScope", reflect.TypeOf((*MockStatusRepository)(nil).GetByScope), ctx, userID, projectID)
+}
+
+// Update mocks base method.
+func (m *MockStatusRepository) Update(ctx context.Context, status *entity.Status) (*entity.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, status)
+	ret0, _ := ret[0].(*entity.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusRepositoryMockRecorder) Update(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatusRepository)(nil).Update), ctx, status)
+}
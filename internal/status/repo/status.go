@@ -0,0 +1,221 @@
+package repo
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/apperror"
+	pgxError "go-todolist-sber/internal/apperror/pgx_errors"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/status"
+	"go-todolist-sber/pkg/postgres"
+)
+
+const statusColumns = `id, id_user, project_id, name, position, done, created_at,
+				array(select to_status_id from status_transition where from_status_id = status.id order by to_status_id)`
+
+type statusRepository struct {
+	*postgres.Postgres
+}
+
+func NewStatusRepository(postgres *postgres.Postgres) status.StatusRepository {
+	return &statusRepository{
+		postgres,
+	}
+}
+
+func (s *statusRepository) collectRow(row pgx.Row) (*entity.Status, error) {
+	var status entity.Status
+	err := row.Scan(&status.ID, &status.UserID, &status.ProjectID, &status.Name, &status.Position, &status.Done, &status.CreatedAt,
+		&status.Transitions)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	errCode := pgxError.ErrorCode(err)
+	if errCode == pgxError.ForeignKeyViolation {
+		return nil, apperror.ErrForeignKeyViolation
+	}
+	if errCode == pgxError.UniqueViolation {
+		return nil, apperror.ErrUniqueViolation
+	}
+	return &status, err
+}
+
+func (s *statusRepository) collectRows(rows pgx.Rows) ([]entity.Status, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Status, error) {
+		status, err := s.collectRow(row)
+		if err != nil {
+			return entity.Status{}, err
+		}
+		return *status, nil
+	})
+}
+
+func (s *statusRepository) getByScope(ctx context.Context, q postgres.Querier, userID string, projectID *int) ([]entity.Status, error) {
+	query := `select ` + statusColumns + ` from status
+				where id_user = $1 and project_id is not distinct from $2
+				order by position, id`
+
+	rows, err := q.Query(ctx, query, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.collectRows(rows)
+}
+
+// checkProject проверяет, что проект принадлежит пользователю
+func (s *statusRepository) checkProject(ctx context.Context, q postgres.Querier, projectID *int, userID string) error {
+	if projectID == nil {
+		return nil
+	}
+
+	query := `select exists (select 1 from project where id = $1 and id_user = $2)`
+
+	var ok bool
+	if err := q.QueryRow(ctx, query, *projectID, userID).Scan(&ok); err != nil {
+		return err
+	}
+	if !ok {
+		return apperror.ErrDataNotValid
+	}
+
+	return nil
+}
+
+// setTransitions заменяет разрешенные переходы из статуса, все статусы должны быть из того же набора
+func (s *statusRepository) setTransitions(ctx context.Context, q postgres.Querier, status *entity.Status) error {
+	if status.Transitions == nil {
+		return nil
+	}
+
+	if _, err := q.Exec(ctx, `delete from status_transition where from_status_id = $1`, status.ID); err != nil {
+		return err
+	}
+	if len(status.Transitions) == 0 {
+		return nil
+	}
+
+	unique := make(map[int]struct{}, len(status.Transitions))
+	for _, id := range status.Transitions {
+		unique[id] = struct{}{}
+	}
+
+	query := `insert into status_transition (from_status_id, to_status_id)
+				select $1, id from status where id = any($2) and id_user = $3 and project_id is not distinct from $4`
+
+	tag, err := q.Exec(ctx, query, status.ID, status.Transitions, status.UserID, status.ProjectID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() != int64(len(unique)) {
+		return apperror.ErrDataNotValid
+	}
+
+	return nil
+}
+
+func (s *statusRepository) Create(ctx context.Context, status *entity.Status) (*entity.Status, error) {
+	query := `insert into status (id_user,project_id,name,position,done)
+				values ($1,$2,$3,coalesce($4,(select coalesce(max(position) + 1, 0) from status
+					where id_user = $1 and project_id is not distinct from $2)),$5)
+				returning ` + statusColumns
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkProject(ctx, tx, status.ProjectID, status.UserID); err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(ctx, query, status.UserID, status.ProjectID, status.Name, status.Position, status.Done)
+	createdStatus, err := s.collectRow(row)
+	if err != nil {
+		return nil, err
+	}
+
+	createdStatus.Transitions = status.Transitions
+	if err := s.setTransitions(ctx, tx, createdStatus); err != nil {
+		return nil, err
+	}
+
+	createdStatus, err = s.collectRow(tx.QueryRow(ctx, `select `+statusColumns+` from status where id = $1`, createdStatus.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return createdStatus, tx.Commit(ctx)
+}
+
+// CreateDefaults создает пользователю набор статусов по порядку и возвращает все его статусы.
+// Уже существующие статусы с тем же именем пропускаются, поэтому повторный вызов безопасен
+func (s *statusRepository) CreateDefaults(ctx context.Context, userID string, statuses []entity.Status) ([]entity.Status, error) {
+	query := `insert into status (id_user,name,position,done) values ($1,$2,$3,$4)
+				on conflict (id_user, coalesce(project_id, 0), lower(name)) do nothing`
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	for i, status := range statuses {
+		if _, err := tx.Exec(ctx, query, userID, status.Name, i, status.Done); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.getByScope(ctx, tx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, tx.Commit(ctx)
+}
+
+func (s *statusRepository) Update(ctx context.Context, status *entity.Status) (*entity.Status, error) {
+	query := `update status set name = $1, position = coalesce($2, position), done = $3 where id = $4 returning ` + statusColumns
+
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	updatedStatus, err := s.collectRow(tx.QueryRow(ctx, query, status.Name, status.Position, status.Done, status.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	updatedStatus.Transitions = status.Transitions
+	if err := s.setTransitions(ctx, tx, updatedStatus); err != nil {
+		return nil, err
+	}
+
+	updatedStatus, err = s.collectRow(tx.QueryRow(ctx, `select `+statusColumns+` from status where id = $1`, status.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedStatus, tx.Commit(ctx)
+}
+
+func (s *statusRepository) GetByScope(ctx context.Context, userID string, projectID *int) ([]entity.Status, error) {
+	return s.getByScope(ctx, s.Pool, userID, projectID)
+}
+
+func (s *statusRepository) GetByID(ctx context.Context, id int) (*entity.Status, error) {
+	query := `select ` + statusColumns + ` from status where id = $1`
+
+	row := s.Conn(ctx).QueryRow(ctx, query, id)
+	return s.collectRow(row)
+}
+
+func (s *statusRepository) DeleteByID(ctx context.Context, id int) error {
+	query := `delete from status where id = $1`
+
+	_, err := s.Pool.Exec(ctx, query, id)
+	return err
+}
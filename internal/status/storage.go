@@ -0,0 +1,16 @@
+package status
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+//go:generate mockgen -source storage.go -destination mock/status_repository_mock.go -package mock
+type StatusRepository interface {
+	Create(ctx context.Context, status *entity.Status) (*entity.Status, error)
+	CreateDefaults(ctx context.Context, userID string, statuses []entity.Status) ([]entity.Status, error)
+	Update(ctx context.Context, status *entity.Status) (*entity.Status, error)
+	GetByScope(ctx context.Context, userID string, projectID *int) ([]entity.Status, error)
+	GetByID(ctx context.Context, id int) (*entity.Status, error)
+	DeleteByID(ctx context.Context, id int) error
+}
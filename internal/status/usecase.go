@@ -0,0 +1,15 @@
+package status
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+type StatusUsecase interface {
+	CreateStatus(ctx context.Context, status *entity.Status) (*entity.Status, error)
+	UpdateStatus(ctx context.Context, status *entity.Status) (*entity.Status, error)
+	GetWorkflow(ctx context.Context, userID string, projectID *int) ([]entity.Status, error)
+	GetStatus(ctx context.Context, id int) (*entity.Status, error)
+	DeleteStatus(ctx context.Context, id int) error
+	IsEqualUserID(ctx context.Context, contextUserID string, statusID int) (bool, error)
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/status"
+	"strings"
+)
+
+type statusUsecase struct {
+	statusRepo status.StatusRepository
+}
+
+func NewStatusUsecase(statusRepo status.StatusRepository) status.StatusUsecase {
+	return &statusUsecase{
+		statusRepo: statusRepo,
+	}
+}
+
+func (s *statusUsecase) CreateStatus(ctx context.Context, status *entity.Status) (*entity.Status, error) {
+	status.Name = strings.TrimSpace(status.Name)
+	if !entity.IsStatusNameValid(status.Name) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	// Своя колонка пользователя добавляется к статусам по умолчанию, а не заменяет их
+	if status.ProjectID == nil {
+		if _, err := s.GetWorkflow(ctx, status.UserID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	status, err := s.statusRepo.Create(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (s *statusUsecase) UpdateStatus(ctx context.Context, status *entity.Status) (*entity.Status, error) {
+	status.Name = strings.TrimSpace(status.Name)
+	if !entity.IsStatusNameValid(status.Name) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	status, err := s.statusRepo.Update(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetWorkflow возвращает колонки доски: статусы проекта, если они заданы, иначе статусы пользователя.
+// При первом обращении пользователю создаются статусы по умолчанию
+func (s *statusUsecase) GetWorkflow(ctx context.Context, userID string, projectID *int) ([]entity.Status, error) {
+	if projectID != nil {
+		statuses, err := s.statusRepo.GetByScope(ctx, userID, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(statuses) > 0 {
+			return statuses, nil
+		}
+	}
+
+	statuses, err := s.statusRepo.GetByScope(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) > 0 {
+		return statuses, nil
+	}
+
+	return s.statusRepo.CreateDefaults(ctx, userID, entity.DefaultStatuses)
+}
+
+func (s *statusUsecase) GetStatus(ctx context.Context, id int) (*entity.Status, error) {
+	return s.statusRepo.GetByID(ctx, id)
+}
+
+func (s *statusUsecase) DeleteStatus(ctx context.Context, id int) error {
+	if err := s.statusRepo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *statusUsecase) IsEqualUserID(ctx context.Context, contextUserID string, statusID int) (bool, error) {
+	data, err := s.statusRepo.GetByID(ctx, statusID)
+	if err != nil {
+		return false, err
+	}
+
+	if data.UserID != contextUserID {
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/status/mock"
+	"testing"
+)
+
+func TestStatusUsecase_GetWorkflow(t *testing.T) {
+	t.Parallel()
+
+	projectID := 7
+	projectStatuses := []entity.Status{{ID: 10, ProjectID: &projectID, Name: "open"}, {ID: 11, ProjectID: &projectID, Name: "closed", Done: true}}
+	userStatuses := []entity.Status{{ID: 1, Name: "todo"}, {ID: 2, Name: "done", Done: true}}
+
+	type mockBehavior func(r *mock.MockStatusRepository)
+
+	tests := []struct {
+		name         string
+		projectID    *int
+		mockBehavior mockBehavior
+		want         []entity.Status
+	}{
+		{
+			name:      "project statuses",
+			projectID: &projectID,
+			mockBehavior: func(r *mock.MockStatusRepository) {
+				r.EXPECT().GetByScope(context.Background(), "uuid", &projectID).Return(projectStatuses, nil)
+			},
+			want: projectStatuses,
+		},
+		{
+			name:      "project without statuses",
+			projectID: &projectID,
+			mockBehavior: func(r *mock.MockStatusRepository) {
+				r.EXPECT().GetByScope(context.Background(), "uuid", &projectID).Return([]entity.Status{}, nil)
+				r.EXPECT().GetByScope(context.Background(), "uuid", nil).Return(userStatuses, nil)
+			},
+			want: userStatuses,
+		},
+		{
+			name:      "defaults on first use",
+			projectID: nil,
+			mockBehavior: func(r *mock.MockStatusRepository) {
+				r.EXPECT().GetByScope(context.Background(), "uuid", nil).Return([]entity.Status{}, nil)
+				r.EXPECT().CreateDefaults(context.Background(), "uuid", entity.DefaultStatuses).Return(userStatuses, nil)
+			},
+			want: userStatuses,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStatusRepo := mock.NewMockStatusRepository(ctrl)
+			tt.mockBehavior(mockStatusRepo)
+
+			statusUsecase := NewStatusUsecase(mockStatusRepo)
+			statuses, err := statusUsecase.GetWorkflow(context.Background(), "uuid", tt.projectID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, statuses)
+		})
+	}
+}
+
+func TestStatusUsecase_CreateStatus(t *testing.T) {
+	t.Parallel()
+
+	projectID := 7
+
+	type mockBehavior func(r *mock.MockStatusRepository)
+
+	tests := []struct {
+		name         string
+		status       *entity.Status
+		mockBehavior mockBehavior
+		want         *entity.Status
+		wantErr      error
+	}{
+		{
+			name:   "user column after defaults",
+			status: &entity.Status{UserID: "uuid", Name: " blocked "},
+			mockBehavior: func(r *mock.MockStatusRepository) {
+				gomock.InOrder(
+					r.EXPECT().GetByScope(context.Background(), "uuid", nil).Return([]entity.Status{}, nil),
+					r.EXPECT().CreateDefaults(context.Background(), "uuid", entity.DefaultStatuses).Return([]entity.Status{{ID: 1}}, nil),
+					r.EXPECT().Create(context.Background(), &entity.Status{UserID: "uuid", Name: "blocked"}).
+						Return(&entity.Status{ID: 6, UserID: "uuid", Name: "blocked"}, nil),
+				)
+			},
+			want:    &entity.Status{ID: 6, UserID: "uuid", Name: "blocked"},
+			wantErr: nil,
+		},
+		{
+			name:   "project column",
+			status: &entity.Status{UserID: "uuid", ProjectID: &projectID, Name: "qa", Transitions: []int{10}},
+			mockBehavior: func(r *mock.MockStatusRepository) {
+				r.EXPECT().Create(context.Background(), &entity.Status{UserID: "uuid", ProjectID: &projectID, Name: "qa", Transitions: []int{10}}).
+					Return(&entity.Status{ID: 12, UserID: "uuid", ProjectID: &projectID, Name: "qa", Transitions: []int{10}}, nil)
+			},
+			want:    &entity.Status{ID: 12, UserID: "uuid", ProjectID: &projectID, Name: "qa", Transitions: []int{10}},
+			wantErr: nil,
+		},
+		{
+			name:         "empty name",
+			status:       &entity.Status{UserID: "uuid", Name: "  "},
+			mockBehavior: func(r *mock.MockStatusRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStatusRepo := mock.NewMockStatusRepository(ctrl)
+			tt.mockBehavior(mockStatusRepo)
+
+			statusUsecase := NewStatusUsecase(mockStatusRepo)
+			status, err := statusUsecase.CreateStatus(context.Background(), tt.status)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
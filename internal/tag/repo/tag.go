@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/apperror"
+	pgxError "go-todolist-sber/internal/apperror/pgx_errors"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/tag"
+	"go-todolist-sber/pkg/postgres"
+)
+
+type tagRepository struct {
+	*postgres.Postgres
+}
+
+func NewTagRepository(postgres *postgres.Postgres) tag.TagRepository {
+	return &tagRepository{
+		postgres,
+	}
+}
+
+func (t *tagRepository) collectRow(row pgx.Row) (*entity.Tag, error) {
+	var tag entity.Tag
+	err := row.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	errCode := pgxError.ErrorCode(err)
+	if errCode == pgxError.ForeignKeyViolation {
+		return nil, apperror.ErrForeignKeyViolation
+	}
+	if errCode == pgxError.UniqueViolation {
+		return nil, apperror.ErrUniqueViolation
+	}
+	return &tag, err
+}
+
+func (t *tagRepository) collectRows(rows pgx.Rows) ([]entity.Tag, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Tag, error) {
+		tag, err := t.collectRow(row)
+		if err != nil {
+			return entity.Tag{}, err
+		}
+		return *tag, nil
+	})
+}
+
+func (t *tagRepository) Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	query := `insert into tag (id_user,name) values ($1,$2) returning id, id_user, name, created_at`
+
+	row := t.Pool.QueryRow(ctx, query, tag.UserID, tag.Name)
+	return t.collectRow(row)
+}
+
+func (t *tagRepository) Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	query := `update tag set name = $1 where id = $2 returning id, id_user, name, created_at`
+
+	row := t.Pool.QueryRow(ctx, query, tag.Name, tag.ID)
+	return t.collectRow(row)
+}
+
+func (t *tagRepository) GetByUserID(ctx context.Context, userID string) ([]entity.Tag, error) {
+	query := `select id, id_user, name, created_at from tag
+				where id_user = $1
+				order by name`
+
+	rows, err := t.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.collectRows(rows)
+}
+
+func (t *tagRepository) GetByID(ctx context.Context, id int) (*entity.Tag, error) {
+	query := `select id, id_user, name, created_at from tag where id = $1`
+
+	row := t.Pool.QueryRow(ctx, query, id)
+	return t.collectRow(row)
+}
+
+func (t *tagRepository) DeleteByID(ctx context.Context, id int) error {
+	query := `delete from tag where id = $1`
+
+	_, err := t.Pool.Exec(ctx, query, id)
+	return err
+}
@@ -0,0 +1,15 @@
+package tag
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+//go:generate mockgen -source storage.go -destination mock/tag_repository_mock.go -package mock
+type TagRepository interface {
+	Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+	Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+	GetByUserID(ctx context.Context, userID string) ([]entity.Tag, error)
+	GetByID(ctx context.Context, id int) (*entity.Tag, error)
+	DeleteByID(ctx context.Context, id int) error
+}
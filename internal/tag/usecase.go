@@ -0,0 +1,14 @@
+package tag
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+type TagUsecase interface {
+	CreateTag(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+	UpdateTag(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+	GetUserTags(ctx context.Context, userID string) ([]entity.Tag, error)
+	DeleteTag(ctx context.Context, id int) error
+	IsEqualUserID(ctx context.Context, contextUserID string, tagID int) (bool, error)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/tag"
+	"strings"
+)
+
+type tagUsecase struct {
+	tagRepo tag.TagRepository
+}
+
+func NewTagUsecase(tagRepo tag.TagRepository) tag.TagUsecase {
+	return &tagUsecase{
+		tagRepo: tagRepo,
+	}
+}
+
+func (t *tagUsecase) CreateTag(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	tag.Name = strings.TrimSpace(tag.Name)
+	if !entity.IsTagNameValid(tag.Name) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	tag, err := t.tagRepo.Create(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (t *tagUsecase) UpdateTag(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	tag.Name = strings.TrimSpace(tag.Name)
+	if !entity.IsTagNameValid(tag.Name) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	tag, err := t.tagRepo.Update(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (t *tagUsecase) GetUserTags(ctx context.Context, userID string) ([]entity.Tag, error) {
+	tags, err := t.tagRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (t *tagUsecase) DeleteTag(ctx context.Context, id int) error {
+	if err := t.tagRepo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *tagUsecase) IsEqualUserID(ctx context.Context, contextUserID string, tagID int) (bool, error) {
+	data, err := t.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		return false, err
+	}
+
+	if data.UserID != contextUserID {
+		return false, nil
+	}
+
+	return true, nil
+}
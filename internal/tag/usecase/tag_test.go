@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/tag/mock"
+	"testing"
+)
+
+func TestTagUsecase_CreateTag(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *mock.MockTagRepository, tag *entity.Tag)
+
+	tests := []struct {
+		name         string
+		tag          *entity.Tag
+		mockBehavior mockBehavior
+		want         *entity.Tag
+		wantErr      error
+	}{
+		{
+			name: "ok",
+			tag:  &entity.Tag{UserID: "uuid", Name: " work "},
+			mockBehavior: func(r *mock.MockTagRepository, tag *entity.Tag) {
+				r.EXPECT().Create(context.Background(), gomock.Eq(&entity.Tag{UserID: "uuid", Name: "work"})).
+					Return(&entity.Tag{ID: 1, UserID: "uuid", Name: "work"}, nil)
+			},
+			want:    &entity.Tag{ID: 1, UserID: "uuid", Name: "work"},
+			wantErr: nil,
+		},
+		{
+			name:         "empty name",
+			tag:          &entity.Tag{UserID: "uuid", Name: "  "},
+			mockBehavior: func(r *mock.MockTagRepository, tag *entity.Tag) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name: "already exists",
+			tag:  &entity.Tag{UserID: "uuid", Name: "work"},
+			mockBehavior: func(r *mock.MockTagRepository, tag *entity.Tag) {
+				r.EXPECT().Create(context.Background(), gomock.Eq(tag)).Return(nil, apperror.ErrUniqueViolation)
+			},
+			want:    nil,
+			wantErr: apperror.ErrUniqueViolation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			tt.mockBehavior(mockTagRepo, tt.tag)
+
+			tagUsecase := NewTagUsecase(mockTagRepo)
+			tag, err := tagUsecase.CreateTag(context.Background(), tt.tag)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, tag)
+		})
+	}
+}
+
+func TestTagUsecase_IsEqualUserID(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *mock.MockTagRepository, tagID int)
+
+	tests := []struct {
+		name         string
+		userID       string
+		tagID        int
+		mockBehavior mockBehavior
+		want         bool
+		wantErr      error
+	}{
+		{
+			name:   "ok",
+			userID: "uuid",
+			tagID:  1,
+			mockBehavior: func(r *mock.MockTagRepository, tagID int) {
+				r.EXPECT().GetByID(context.Background(), tagID).Return(&entity.Tag{UserID: "uuid"}, nil)
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name:   "another user",
+			userID: "uuid",
+			tagID:  2,
+			mockBehavior: func(r *mock.MockTagRepository, tagID int) {
+				r.EXPECT().GetByID(context.Background(), tagID).Return(&entity.Tag{UserID: "other"}, nil)
+			},
+			want:    false,
+			wantErr: nil,
+		},
+		{
+			name:   "not found",
+			userID: "uuid",
+			tagID:  3,
+			mockBehavior: func(r *mock.MockTagRepository, tagID int) {
+				r.EXPECT().GetByID(context.Background(), tagID).Return(nil, apperror.ErrNoRows)
+			},
+			want:    false,
+			wantErr: apperror.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			tt.mockBehavior(mockTagRepo, tt.tagID)
+
+			tagUsecase := NewTagUsecase(mockTagRepo)
+			equal, err := tagUsecase.IsEqualUserID(context.Background(), tt.userID, tt.tagID)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, equal)
+		})
+	}
+}
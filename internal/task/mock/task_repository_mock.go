@@ -36,6 +36,22 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockTaskRepository) Bulk(ctx context.Context, bulk *entity.Bulk) ([]entity.BulkResult, []int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, bulk)
+	ret0, _ := ret[0].([]entity.BulkResult)
+	ret1, _ := ret[1].([]int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockTaskRepositoryMockRecorder) Bulk(ctx, bulk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTaskRepository)(nil).Bulk), ctx, bulk)
+}
+
 // Create mocks base method.
 func (m *MockTaskRepository) Create(ctx context.Context, task *entity.Task) (*entity.Task, error) {
 	m.ctrl.T.Helper()
@@ -52,62 +68,65 @@ func (mr *MockTaskRepositoryMockRecorder) Create(ctx, task interface{}) *gomock.
 }
 
 // DeleteByID mocks base method.
-func (m *MockTaskRepository) DeleteByID(ctx context.Context, id int) error {
+func (m *MockTaskRepository) DeleteByID(ctx context.Context, id int, deletedAt time.Time) ([]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id, deletedAt)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockTaskRepositoryMockRecorder) DeleteByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) DeleteByID(ctx, id, deletedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockTaskRepository)(nil).DeleteByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockTaskRepository)(nil).DeleteByID), ctx, id, deletedAt)
 }
 
-// GetAll mocks base method.
-func (m *MockTaskRepository) GetAll(ctx context.Context) ([]entity.Task, error) {
+// DeletePermanently mocks base method.
+func (m *MockTaskRepository) DeletePermanently(ctx context.Context, id int, userID string) ([]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
-	ret0, _ := ret[0].([]entity.Task)
+	ret := m.ctrl.Call(m, "DeletePermanently", ctx, id, userID)
+	ret0, _ := ret[0].([]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAll indicates an expected call of GetAll.
-func (mr *MockTaskRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+// DeletePermanently indicates an expected call of DeletePermanently.
+func (mr *MockTaskRepositoryMockRecorder) DeletePermanently(ctx, id, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTaskRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermanently", reflect.TypeOf((*MockTaskRepository)(nil).DeletePermanently), ctx, id, userID)
 }
 
-// GetByDateAndStatus mocks base method.
-func (m *MockTaskRepository) GetByDateAndStatus(ctx context.Context, userID string, date time.Time, status bool) ([]entity.Task, error) {
+// Find mocks base method.
+func (m *MockTaskRepository) Find(ctx context.Context, query *entity.TaskQuery) ([]entity.Task, int, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByDateAndStatus", ctx, userID, date, status)
+	ret := m.ctrl.Call(m, "Find", ctx, query)
 	ret0, _ := ret[0].([]entity.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
-// GetByDateAndStatus indicates an expected call of GetByDateAndStatus.
-func (mr *MockTaskRepositoryMockRecorder) GetByDateAndStatus(ctx, userID, date, status interface{}) *gomock.Call {
+// Find indicates an expected call of Find.
+func (mr *MockTaskRepositoryMockRecorder) Find(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDateAndStatus", reflect.TypeOf((*MockTaskRepository)(nil).GetByDateAndStatus), ctx, userID, date, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockTaskRepository)(nil).Find), ctx, query)
 }
 
-// GetByDateAndStatusWithOffset mocks base method.
-func (m *MockTaskRepository) GetByDateAndStatusWithOffset(ctx context.Context, userID string, date time.Time, status bool, offset int) ([]entity.Task, error) {
+// GetAll mocks base method.
+func (m *MockTaskRepository) GetAll(ctx context.Context) ([]entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByDateAndStatusWithOffset", ctx, userID, date, status, offset)
+	ret := m.ctrl.Call(m, "GetAll", ctx)
 	ret0, _ := ret[0].([]entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByDateAndStatusWithOffset indicates an expected call of GetByDateAndStatusWithOffset.
-func (mr *MockTaskRepositoryMockRecorder) GetByDateAndStatusWithOffset(ctx, userID, date, status, offset interface{}) *gomock.Call {
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTaskRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDateAndStatusWithOffset", reflect.TypeOf((*MockTaskRepository)(nil).GetByDateAndStatusWithOffset), ctx, userID, date, status, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTaskRepository)(nil).GetAll), ctx)
 }
 
 // GetByID mocks base method.
@@ -125,64 +144,96 @@ func (mr *MockTaskRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTaskRepository)(nil).GetByID), ctx, id)
 }
 
-// GetByStatus mocks base method.
-func (m *MockTaskRepository) GetByStatus(ctx context.Context, userID string, status bool) ([]entity.Task, error) {
+// GetByParentID mocks base method.
+func (m *MockTaskRepository) GetByParentID(ctx context.Context, parentID int) ([]entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByStatus", ctx, userID, status)
+	ret := m.ctrl.Call(m, "GetByParentID", ctx, parentID)
 	ret0, _ := ret[0].([]entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByStatus indicates an expected call of GetByStatus.
-func (mr *MockTaskRepositoryMockRecorder) GetByStatus(ctx, userID, status interface{}) *gomock.Call {
+// GetByParentID indicates an expected call of GetByParentID.
+func (mr *MockTaskRepositoryMockRecorder) GetByParentID(ctx, parentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatus", reflect.TypeOf((*MockTaskRepository)(nil).GetByStatus), ctx, userID, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByParentID", reflect.TypeOf((*MockTaskRepository)(nil).GetByParentID), ctx, parentID)
 }
 
-// GetByStatusWithOffset mocks base method.
-func (m *MockTaskRepository) GetByStatusWithOffset(ctx context.Context, userID string, status bool, offset int) ([]entity.Task, error) {
+// GetByStatusID mocks base method.
+func (m *MockTaskRepository) GetByStatusID(ctx context.Context, statusID int) ([]entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByStatusWithOffset", ctx, userID, status, offset)
+	ret := m.ctrl.Call(m, "GetByStatusID", ctx, statusID)
 	ret0, _ := ret[0].([]entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByStatusWithOffset indicates an expected call of GetByStatusWithOffset.
-func (mr *MockTaskRepositoryMockRecorder) GetByStatusWithOffset(ctx, userID, status, offset interface{}) *gomock.Call {
+// GetByStatusID indicates an expected call of GetByStatusID.
+func (mr *MockTaskRepositoryMockRecorder) GetByStatusID(ctx, statusID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatusWithOffset", reflect.TypeOf((*MockTaskRepository)(nil).GetByStatusWithOffset), ctx, userID, status, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatusID", reflect.TypeOf((*MockTaskRepository)(nil).GetByStatusID), ctx, statusID)
 }
 
-// GetByUserID mocks base method.
-func (m *MockTaskRepository) GetByUserID(ctx context.Context, id string) ([]entity.Task, error) {
+// Purge mocks base method.
+func (m *MockTaskRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByUserID", ctx, id)
-	ret0, _ := ret[0].([]entity.Task)
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByUserID indicates an expected call of GetByUserID.
-func (mr *MockTaskRepositoryMockRecorder) GetByUserID(ctx, id interface{}) *gomock.Call {
+// Purge indicates an expected call of Purge.
+func (mr *MockTaskRepositoryMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockTaskRepository)(nil).GetByUserID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTaskRepository)(nil).Purge), ctx, before)
 }
 
-// GetByUserIDWithOffset mocks base method.
-func (m *MockTaskRepository) GetByUserIDWithOffset(ctx context.Context, id string, offset int) ([]entity.Task, error) {
+// Replace mocks base method.
+func (m *MockTaskRepository) Replace(ctx context.Context, task *entity.Task) (*entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByUserIDWithOffset", ctx, id, offset)
-	ret0, _ := ret[0].([]entity.Task)
+	ret := m.ctrl.Call(m, "Replace", ctx, task)
+	ret0, _ := ret[0].(*entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByUserIDWithOffset indicates an expected call of GetByUserIDWithOffset.
-func (mr *MockTaskRepositoryMockRecorder) GetByUserIDWithOffset(ctx, id, offset interface{}) *gomock.Call {
+// Replace indicates an expected call of Replace.
+func (mr *MockTaskRepositoryMockRecorder) Replace(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTaskRepository)(nil).Replace), ctx, task)
+}
+
+// Restore mocks base method.
+func (m *MockTaskRepository) Restore(ctx context.Context, id int, userID string) (*entity.Task, []int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userID)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].([]int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTaskRepositoryMockRecorder) Restore(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTaskRepository)(nil).Restore), ctx, id, userID)
+}
+
+// Search mocks base method.
+func (m *MockTaskRepository) Search(ctx context.Context, query *entity.TaskQuery) ([]entity.SearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].([]entity.SearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTaskRepositoryMockRecorder) Search(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDWithOffset", reflect.TypeOf((*MockTaskRepository)(nil).GetByUserIDWithOffset), ctx, id, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTaskRepository)(nil).Search), ctx, query)
 }
 
 // Update mocks base method.
@@ -201,16 +252,92 @@ func (mr *MockTaskRepositoryMockRecorder) Update(ctx, task interface{}) *gomock.
 }
 
 // UpdateDone mocks base method.
-func (m *MockTaskRepository) UpdateDone(ctx context.Context, status bool, taskID int) (*entity.Task, error) {
+func (m *MockTaskRepository) UpdateDone(ctx context.Context, status bool, taskID, version int) (*entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDone", ctx, status, taskID)
+	ret := m.ctrl.Call(m, "UpdateDone", ctx, status, taskID, version)
 	ret0, _ := ret[0].(*entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateDone indicates an expected call of UpdateDone.
-func (mr *MockTaskRepositoryMockRecorder) UpdateDone(ctx, status, taskID interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) UpdateDone(ctx, status, taskID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDone", reflect.TypeOf((*MockTaskRepository)(nil).UpdateDone), ctx, status, taskID, version)
+}
+
+// UpdateDoneWithChildren mocks base method.
+func (m *MockTaskRepository) UpdateDoneWithChildren(ctx context.Context, status bool, taskID, version int) (*entity.Task, []int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDoneWithChildren", ctx, status, taskID, version)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].([]int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateDoneWithChildren indicates an expected call of UpdateDoneWithChildren.
+func (mr *MockTaskRepositoryMockRecorder) UpdateDoneWithChildren(ctx, status, taskID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDoneWithChildren", reflect.TypeOf((*MockTaskRepository)(nil).UpdateDoneWithChildren), ctx, status, taskID, version)
+}
+
+// UpdatePosition mocks base method.
+func (m *MockTaskRepository) UpdatePosition(ctx context.Context, taskID int, userID string, afterID, beforeID *int) (*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePosition", ctx, taskID, userID, afterID, beforeID)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePosition indicates an expected call of UpdatePosition.
+func (mr *MockTaskRepositoryMockRecorder) UpdatePosition(ctx, taskID, userID, afterID, beforeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePosition", reflect.TypeOf((*MockTaskRepository)(nil).UpdatePosition), ctx, taskID, userID, afterID, beforeID)
+}
+
+// UpdateProject mocks base method.
+func (m *MockTaskRepository) UpdateProject(ctx context.Context, taskID int, userID string, projectID *int) (*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, taskID, userID, projectID)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockTaskRepositoryMockRecorder) UpdateProject(ctx, taskID, userID, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockTaskRepository)(nil).UpdateProject), ctx, taskID, userID, projectID)
+}
+
+// UpdateSeries mocks base method.
+func (m *MockTaskRepository) UpdateSeries(ctx context.Context, seriesID int, task *entity.Task) (*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeries", ctx, seriesID, task)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSeries indicates an expected call of UpdateSeries.
+func (mr *MockTaskRepositoryMockRecorder) UpdateSeries(ctx, seriesID, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeries", reflect.TypeOf((*MockTaskRepository)(nil).UpdateSeries), ctx, seriesID, task)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockTaskRepository) UpdateStatus(ctx context.Context, taskID, statusID int, done bool, version int) (*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, taskID, statusID, done, version)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockTaskRepositoryMockRecorder) UpdateStatus(ctx, taskID, statusID, done, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDone", reflect.TypeOf((*MockTaskRepository)(nil).UpdateDone), ctx, status, taskID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockTaskRepository)(nil).UpdateStatus), ctx, taskID, statusID, done, version)
 }
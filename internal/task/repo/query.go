@@ -0,0 +1,287 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/entity"
+	"strings"
+)
+
+// Find возвращает задачи по спецификации query одним запросом выборки и, для постраничной выдачи,
+// одним запросом количества. total — количество задач под фильтром без учета пагинации,
+// more сообщает, есть ли задачи дальше в направлении выборки при курсорной пагинации
+func (t *taskRepository) Find(ctx context.Context, query *entity.TaskQuery) ([]entity.Task, int, bool, error) {
+	var builder strings.Builder
+
+	builder.WriteString(` from task`)
+	args := applyWhere(&builder, nil, query.UserID, query.Filter)
+
+	total, err := t.count(ctx, query, builder.String(), args)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	cursor, before := query.After, false
+	if query.Before != nil {
+		cursor, before = query.Before, true
+	}
+	if cursor != nil {
+		args = applyCursor(&builder, args, cursor, before)
+	}
+
+	builder.WriteString(` order by ` + strings.Join(orderColumns(query.Sort, before), ", "))
+	args = applyPage(&builder, args, query)
+
+	tasks, err := t.query(ctx, t.Conn(ctx), `select `+taskColumns+builder.String(), args...)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if !query.IsPaged() {
+		total = len(tasks)
+	}
+
+	more := query.CursorMode && len(tasks) > query.PageSize
+	if more {
+		tasks = tasks[:query.PageSize]
+	}
+	if before {
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+	}
+
+	return tasks, total, more, nil
+}
+
+// Search ищет задачи по query.Filter.Text и сортирует их по релевантности, при равной релевантности — по query.Sort.
+// Заголовок весит больше описания, совпадения в headline и snippet выделяются тегом <mark>
+func (t *taskRepository) Search(ctx context.Context, query *entity.TaskQuery) ([]entity.SearchResult, int, error) {
+	var builder strings.Builder
+
+	filter := query.Filter
+	filter.Text = ""
+
+	builder.WriteString(` from task, websearch_to_tsquery('simple', $1) tsq`)
+	args := applyWhere(&builder, []interface{}{query.Filter.Text}, query.UserID, filter)
+	builder.WriteString(` and search @@ tsq`)
+
+	total, err := t.count(ctx, query, builder.String(), args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	builder.WriteString(` order by ` + strings.Join(append([]string{"ts_rank(search, tsq) desc"}, orderColumns(query.Sort, false)...), ", "))
+	args = applyPage(&builder, args, query)
+
+	rows, err := t.Conn(ctx).Query(ctx, `select `+taskColumns+`, ts_rank(search, tsq),
+				ts_headline('simple', header, tsq, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'),
+				ts_headline('simple', description, tsq, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')`+
+		builder.String(), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.SearchResult, error) {
+		var result entity.SearchResult
+		err := row.Scan(append(taskFields(&result.Task), &result.Rank, &result.Headline, &result.Snippet)...)
+		return result, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tasks := make([]entity.Task, len(results))
+	for i := range results {
+		tasks[i] = results[i].Task
+	}
+	if err := t.load(ctx, t.Conn(ctx), tasks); err != nil {
+		return nil, 0, err
+	}
+	for i := range results {
+		results[i].Task = tasks[i]
+	}
+	if !query.IsPaged() {
+		total = len(results)
+	}
+
+	return results, total, nil
+}
+
+// count считает задачи под фильтром, для выдачи без пагинации количество берется из самой выборки
+func (t *taskRepository) count(ctx context.Context, query *entity.TaskQuery, from string, args []interface{}) (int, error) {
+	if !query.IsPaged() {
+		return 0, nil
+	}
+
+	var total int
+	err := t.Conn(ctx).QueryRow(ctx, `select count(*)`+from, args...).Scan(&total)
+	return total, err
+}
+
+// applyWhere добавляет условия выборки задач пользователя, общие для запроса количества и запроса страницы
+func applyWhere(builder *strings.Builder, args []interface{}, userID string, filter entity.TaskFilter) []interface{} {
+	args = append(args, userID)
+	builder.WriteString(fmt.Sprintf(` where id_user = $%d`, len(args)))
+
+	if filter.Deleted {
+		builder.WriteString(` and deleted_at is not null`)
+	} else {
+		builder.WriteString(` and deleted_at is null`)
+	}
+
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		builder.WriteString(fmt.Sprintf(` and done = $%d`, len(args)))
+	}
+
+	if filter.Priority != nil {
+		args = append(args, string(*filter.Priority))
+		builder.WriteString(fmt.Sprintf(` and priority = $%d`, len(args)))
+	}
+
+	if filter.Text != "" {
+		args = append(args, filter.Text)
+		builder.WriteString(fmt.Sprintf(` and search @@ websearch_to_tsquery('simple', $%d)`, len(args)))
+	}
+
+	if filter.ProjectID != nil {
+		args = append(args, *filter.ProjectID)
+		builder.WriteString(fmt.Sprintf(` and project_id = $%d`, len(args)))
+	}
+
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		if filter.TagMode == entity.TagModeAll {
+			args = append(args, len(filter.Tags))
+			builder.WriteString(fmt.Sprintf(` and (select count(distinct tt.tag_id) from task_tag tt
+				where tt.task_id = task.id and tt.tag_id = any($%d)) = $%d`, len(args)-1, len(args)))
+		} else {
+			builder.WriteString(fmt.Sprintf(` and exists (select 1 from task_tag tt
+				where tt.task_id = task.id and tt.tag_id = any($%d))`, len(args)))
+		}
+	}
+
+	args = applyRange(builder, args, "start_date", filter.StartRange)
+	args = applyRange(builder, args, "created_at", filter.CreatedRange)
+	args = applyRange(builder, args, "due_date", filter.DueRange)
+
+	today := entity.StartOfDay(filter.Now)
+	switch filter.Due {
+	case entity.DueOverdue:
+		args = append(args, entity.WallClock(filter.Now), today)
+		builder.WriteString(fmt.Sprintf(` and not done and due_date < case when due_has_time then $%d else $%d end`, len(args)-1, len(args)))
+	case entity.DueToday:
+		args = append(args, today, today.AddDate(0, 0, 1))
+		builder.WriteString(fmt.Sprintf(` and due_date >= $%d and due_date < $%d`, len(args)-1, len(args)))
+	case entity.DueThisWeek:
+		weekStart := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		args = append(args, weekStart, weekStart.AddDate(0, 0, 7))
+		builder.WriteString(fmt.Sprintf(` and due_date >= $%d and due_date < $%d`, len(args)-1, len(args)))
+	}
+
+	return args
+}
+
+func applyRange(builder *strings.Builder, args []interface{}, column string, r entity.TimeRange) []interface{} {
+	if r.From != nil {
+		args = append(args, *r.From)
+		builder.WriteString(fmt.Sprintf(` and %s >= $%d`, column, len(args)))
+	}
+	if r.To != nil {
+		args = append(args, *r.To)
+		builder.WriteString(fmt.Sprintf(` and %s <= $%d`, column, len(args)))
+	}
+	return args
+}
+
+// applyPage ограничивает выборку страницей. При курсорной пагинации выбирается на одну задачу больше,
+// чтобы узнать, есть ли следующая страница
+func applyPage(builder *strings.Builder, args []interface{}, query *entity.TaskQuery) []interface{} {
+	switch {
+	case query.CursorMode:
+		args = append(args, query.PageSize+1)
+		builder.WriteString(fmt.Sprintf(` limit $%d`, len(args)))
+	case query.Page > 0:
+		args = append(args, (query.Page-1)*query.PageSize, query.PageSize)
+		builder.WriteString(fmt.Sprintf(` offset $%d limit $%d`, len(args)-1, len(args)))
+	}
+	return args
+}
+
+// orderColumns возвращает выражения сортировки, id в конце делает порядок однозначным для пагинации.
+// reverse обращает порядок целиком, включая положение задач без срока
+func orderColumns(sort entity.TaskSort, reverse bool) []string {
+	sort = sort.Normalize()
+	if reverse {
+		sort.Order = reverseOrder(sort.Order)
+	}
+
+	direction := string(sort.Order)
+	switch sort.Field {
+	case entity.SortByPriority, entity.SortByStartDate, entity.SortByCreatedAt:
+		return []string{string(sort.Field) + " " + direction, "id " + direction}
+	case entity.SortByManual:
+		return []string{"position " + direction, "id " + direction}
+	case entity.SortByDueDate:
+		nulls := " nulls last"
+		if reverse {
+			nulls = " nulls first"
+		}
+		return []string{"due_date " + direction + nulls, "id " + direction}
+	default:
+		return []string{"id " + direction}
+	}
+}
+
+func reverseOrder(order entity.SortOrder) entity.SortOrder {
+	if order == entity.SortAsc {
+		return entity.SortDesc
+	}
+	return entity.SortAsc
+}
+
+// applyCursor оставляет задачи, идущие после курсора, или перед ним при before.
+// Задачи без срока при сортировке по due_date всегда идут в конце списка
+func applyCursor(builder *strings.Builder, args []interface{}, cursor *entity.Cursor, before bool) []interface{} {
+	op := "<"
+	if (cursor.Order == entity.SortAsc) != before {
+		op = ">"
+	}
+
+	args = append(args, cursor.ID)
+	id := len(args)
+
+	var column string
+	switch cursor.SortBy {
+	case entity.SortByPriority, entity.SortByStartDate, entity.SortByCreatedAt, entity.SortByDueDate:
+		column = string(cursor.SortBy)
+	case entity.SortByManual:
+		column = "position"
+	default:
+		builder.WriteString(fmt.Sprintf(` and id %s $%d`, op, id))
+		return args
+	}
+
+	if cursor.Key == nil {
+		if before {
+			builder.WriteString(fmt.Sprintf(` and (%s is not null or id %s $%d)`, column, op, id))
+		} else {
+			builder.WriteString(fmt.Sprintf(` and %s is null and id %s $%d`, column, op, id))
+		}
+		return args
+	}
+
+	args = append(args, cursor.KeyValue())
+	keyset := fmt.Sprintf(`(%s, id) %s ($%d, $%d)`, column, op, len(args), id)
+
+	switch {
+	case cursor.SortBy != entity.SortByDueDate:
+		builder.WriteString(` and ` + keyset)
+	case before:
+		builder.WriteString(fmt.Sprintf(` and %s is not null and %s`, column, keyset))
+	default:
+		builder.WriteString(fmt.Sprintf(` and (%s is null or %s)`, column, keyset))
+	}
+	return args
+}
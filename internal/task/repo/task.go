@@ -8,11 +8,15 @@ import (
 	pgxError "go-todolist-sber/internal/apperror/pgx_errors"
 	"go-todolist-sber/internal/entity"
 	"go-todolist-sber/internal/task"
+	"go-todolist-sber/pkg/lexorank"
 	"go-todolist-sber/pkg/postgres"
 	"strings"
 	"time"
 )
 
+const taskColumns = `id, id_user, header, description, created_at, start_date, done, priority, due_date, due_has_time, project_id, parent_id,
+				rrule, series_id, occurrence, status_id, position, deleted_at, version`
+
 type taskRepository struct {
 	*postgres.Postgres
 }
@@ -23,9 +27,15 @@ func NewTaskRepository(postgres *postgres.Postgres) task.TaskRepository {
 	}
 }
 
+// taskFields возвращает назначения для Scan в порядке taskColumns
+func taskFields(task *entity.Task) []interface{} {
+	return []interface{}{&task.ID, &task.UserID, &task.Header, &task.Description, &task.CreatedAt, &task.StartDate, &task.Done, &task.Priority, &task.DueDate, &task.DueHasTime, &task.ProjectID, &task.ParentID,
+		&task.RRule, &task.SeriesID, &task.Occurrence, &task.StatusID, &task.Position, &task.DeletedAt, &task.Version}
+}
+
 func (t *taskRepository) collectRow(row pgx.Row) (*entity.Task, error) {
 	var task entity.Task
-	err := row.Scan(&task.ID, &task.UserID, &task.Header, &task.Description, &task.CreatedAt, &task.StartDate, &task.Done)
+	err := row.Scan(taskFields(&task)...)
 	if err == pgx.ErrNoRows {
 		return nil, apperror.ErrNoRows
 	}
@@ -42,24 +52,270 @@ func (t *taskRepository) collectRow(row pgx.Row) (*entity.Task, error) {
 func (t *taskRepository) collectRows(rows pgx.Rows) ([]entity.Task, error) {
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Task, error) {
 		task, err := t.collectRow(row)
-		return *task, err
+		if err != nil {
+			return entity.Task{}, err
+		}
+		return *task, nil
 	})
 }
 
+// queryIDs выполняет запрос, возвращающий id задач
+func (t *taskRepository) queryIDs(ctx context.Context, q postgres.Querier, sql string, args ...interface{}) ([]int, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int])
+}
+
+// query выполняет выборку задач и дополняет их метками
+func (t *taskRepository) query(ctx context.Context, q postgres.Querier, sql string, args ...interface{}) ([]entity.Task, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := t.collectRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.load(ctx, q, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (t *taskRepository) queryRow(ctx context.Context, q postgres.Querier, sql string, args ...interface{}) (*entity.Task, error) {
+	task, err := t.collectRow(q.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []entity.Task{*task}
+	if err := t.load(ctx, q, tasks); err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+// load дополняет задачи связанными данными: метками и статистикой подзадач
+func (t *taskRepository) load(ctx context.Context, q postgres.Querier, tasks []entity.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	if err := t.loadTags(ctx, q, tasks); err != nil {
+		return err
+	}
+	return t.loadChildStats(ctx, q, tasks)
+}
+
+func (t *taskRepository) loadChildStats(ctx context.Context, q postgres.Querier, tasks []entity.Task) error {
+	ids := make([]int, 0, len(tasks))
+	index := make(map[int]int, len(tasks))
+	for i := range tasks {
+		ids = append(ids, tasks[i].ID)
+		index[tasks[i].ID] = i
+	}
+
+	query := `select parent_id, count(*), count(*) filter (where done)
+				from task
+				where parent_id = any($1) and deleted_at is null
+				group by parent_id`
+
+	rows, err := q.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var parentID, count, done int
+		if err := rows.Scan(&parentID, &count, &done); err != nil {
+			return err
+		}
+		task := &tasks[index[parentID]]
+		task.ChildCount = count
+		task.ChildDone = done
+		task.Progress = done * 100 / count
+	}
+
+	return rows.Err()
+}
+
+func (t *taskRepository) loadTags(ctx context.Context, q postgres.Querier, tasks []entity.Task) error {
+	ids := make([]int, 0, len(tasks))
+	index := make(map[int]int, len(tasks))
+	for i := range tasks {
+		tasks[i].Tags = []entity.Tag{}
+		ids = append(ids, tasks[i].ID)
+		index[tasks[i].ID] = i
+	}
+
+	query := `select tt.task_id, tg.id, tg.id_user, tg.name, tg.created_at
+				from task_tag tt
+				join tag tg on tg.id = tt.tag_id
+				where tt.task_id = any($1)
+				order by tg.name`
+
+	rows, err := q.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		var tag entity.Tag
+		if err := rows.Scan(&taskID, &tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt); err != nil {
+			return err
+		}
+		i := index[taskID]
+		tasks[i].Tags = append(tasks[i].Tags, tag)
+	}
+
+	return rows.Err()
+}
+
+// checkProject проверяет, что проект принадлежит пользователю и, если archived не разрешен, не находится в архиве
+func (t *taskRepository) checkProject(ctx context.Context, q postgres.Querier, projectID *int, userID string, archived bool) error {
+	if projectID == nil {
+		return nil
+	}
+
+	query := `select exists (select 1 from project where id = $1 and id_user = $2 and ($3 or not archived))`
+
+	var ok bool
+	if err := q.QueryRow(ctx, query, *projectID, userID, archived).Scan(&ok); err != nil {
+		return err
+	}
+	if !ok {
+		return apperror.ErrDataNotValid
+	}
+
+	return nil
+}
+
+// checkParent проверяет, что родительская задача принадлежит тому же пользователю
+func (t *taskRepository) checkParent(ctx context.Context, q postgres.Querier, parentID *int, userID string) error {
+	if parentID == nil {
+		return nil
+	}
+
+	query := `select exists (select 1 from task where id = $1 and id_user = $2 and deleted_at is null)`
+
+	var ok bool
+	if err := q.QueryRow(ctx, query, *parentID, userID).Scan(&ok); err != nil {
+		return err
+	}
+	if !ok {
+		return apperror.ErrDataNotValid
+	}
+
+	return nil
+}
+
+// setTags заменяет набор меток задачи, все метки должны принадлежать владельцу задачи
+func (t *taskRepository) setTags(ctx context.Context, q postgres.Querier, taskID int, userID string, tagIDs []int) error {
+	if tagIDs == nil {
+		return nil
+	}
+
+	if _, err := q.Exec(ctx, `delete from task_tag where task_id = $1`, taskID); err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	unique := make(map[int]struct{}, len(tagIDs))
+	for _, id := range tagIDs {
+		unique[id] = struct{}{}
+	}
+
+	query := `insert into task_tag (task_id, tag_id)
+				select $1, id from tag where id = any($2) and id_user = $3`
+
+	tag, err := q.Exec(ctx, query, taskID, tagIDs, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() != int64(len(unique)) {
+		return apperror.ErrDataNotValid
+	}
+
+	return nil
+}
+
 func (t *taskRepository) Update(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	updatedTask, err := t.update(ctx, tx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTask, tx.Commit(ctx)
+}
+
+// UpdateSeries переносит заголовок, описание, приоритет и правило повторения на все незавершенные
+// повторения серии, остальные поля меняются только у самой задачи
+func (t *taskRepository) UpdateSeries(ctx context.Context, seriesID int, task *entity.Task) (*entity.Task, error) {
+	query := `update task set
+					header = coalesce(nullif($1, ''), header),
+					description = coalesce(nullif($2, ''), description),
+					priority = coalesce(nullif($3, '')::priority, priority),
+					rrule = coalesce(nullif($4, ''), rrule),
+					version = version + 1
+				where series_id = $5 and id <> $6 and not done and deleted_at is null`
+
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, query, task.Header, task.Description, string(task.Priority), task.RRule, seriesID, task.ID); err != nil {
+		return nil, err
+	}
+
+	updatedTask, err := t.update(ctx, tx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTask, tx.Commit(ctx)
+}
+
+func (t *taskRepository) update(ctx context.Context, q postgres.Querier, task *entity.Task) (*entity.Task, error) {
 	var builder strings.Builder
 	increment := 0
 	attribute := []interface{}{}
 
 	builder.WriteString(`update task set `)
 
+	// Признак времени в сроке обновляется только вместе с самим сроком
+	var dueHasTime interface{}
+	if task.DueDate != nil {
+		dueHasTime = task.DueHasTime
+	}
+
 	attributesToUpdate := []struct {
 		name  string
 		value interface{}
 	}{
 		{"header", task.Header},
 		{"description", task.Description},
-		{"created_at", task.StartDate},
+		{"start_date", task.StartDate},
+		{"priority", string(task.Priority)},
+		{"due_date", task.DueDate},
+		{"due_has_time", dueHasTime},
+		{"rrule", task.RRule},
 	}
 
 	commaAdded := false
@@ -76,13 +332,142 @@ func (t *taskRepository) Update(ctx context.Context, task *entity.Task) (*entity
 		}
 	}
 
-	increment++
-	builder.WriteString(fmt.Sprintf(` where id = $%d returning *`, increment))
+	// Версия растет при любом изменении, в том числе когда меняются только метки
+	if commaAdded {
+		builder.WriteString(", ")
+	}
+	builder.WriteString(`version = version + 1`)
 
+	// Задача из корзины не изменяется, как и не читается
+	increment++
+	builder.WriteString(fmt.Sprintf(` where id = $%d and deleted_at is null`, increment))
 	attribute = append(attribute, task.ID)
-	row := t.Pool.QueryRow(ctx, builder.String(), attribute...)
 
-	return t.collectRow(row)
+	// Ненулевая версия задачи — ожидаемая текущая версия, при расхождении строка не обновляется
+	if task.Version != 0 {
+		increment++
+		builder.WriteString(fmt.Sprintf(` and version = $%d`, increment))
+		attribute = append(attribute, task.Version)
+	}
+	builder.WriteString(` returning ` + taskColumns)
+
+	if _, err := t.collectRow(q.QueryRow(ctx, builder.String(), attribute...)); err != nil {
+		return nil, err
+	}
+
+	if err := t.setTags(ctx, q, task.ID, task.UserID, task.TagIDs); err != nil {
+		return nil, err
+	}
+
+	return t.queryRow(ctx, q, `select `+taskColumns+` from task where id = $1`, task.ID)
+}
+
+// Replace записывает все изменяемые поля задачи, в том числе пустые значения: пустое описание и правило
+// повторения, отсутствующие срок, проект и родитель. Новые проект и родитель проверяются так же, как при создании.
+// Ненулевая версия задачи — ожидаемая текущая версия, при расхождении возвращается ErrNoRows
+func (t *taskRepository) Replace(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	query := `update task set
+					header = $1,
+					description = $2,
+					start_date = $3,
+					priority = $4,
+					due_date = $5,
+					due_has_time = $6,
+					done = $7,
+					status_id = case when $7 = coalesce((select s.done from status s where s.id = task.status_id), $7)
+						then status_id end,
+					project_id = $8,
+					parent_id = $9,
+					rrule = $10,
+					version = version + 1
+				where id = $11 and deleted_at is null and ($12 = 0 or version = $12)`
+
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var projectID, parentID *int
+	err = tx.QueryRow(ctx, `select project_id, parent_id from task where id = $1 and deleted_at is null for update`, task.ID).
+		Scan(&projectID, &parentID)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Задача может остаться в архивном проекте, но перенести ее туда нельзя
+	if !equalID(projectID, task.ProjectID) {
+		if err := t.checkProject(ctx, tx, task.ProjectID, task.UserID, false); err != nil {
+			return nil, err
+		}
+	}
+	if !equalID(parentID, task.ParentID) {
+		if err := t.checkParent(ctx, tx, task.ParentID, task.UserID); err != nil {
+			return nil, err
+		}
+		if err := t.checkCycle(ctx, tx, task.ID, task.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	var dueHasTime bool
+	if task.DueDate != nil {
+		dueHasTime = task.DueHasTime
+	}
+
+	tag, err := tx.Exec(ctx, query, task.Header, task.Description, task.StartDate, string(task.Priority), task.DueDate,
+		dueHasTime, task.Done, task.ProjectID, task.ParentID, task.RRule, task.ID, task.Version)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, apperror.ErrNoRows
+	}
+
+	if err := t.setTags(ctx, tx, task.ID, task.UserID, task.TagIDs); err != nil {
+		return nil, err
+	}
+
+	updatedTask, err := t.queryRow(ctx, tx, `select `+taskColumns+` from task where id = $1`, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTask, tx.Commit(ctx)
+}
+
+// checkCycle проверяет, что новый родитель не является самой задачей или ее подзадачей
+func (t *taskRepository) checkCycle(ctx context.Context, q postgres.Querier, taskID int, parentID *int) error {
+	if parentID == nil {
+		return nil
+	}
+
+	query := `with recursive tree as (
+					select id from task where id = $1
+					union all
+					select c.id from task c join tree on c.parent_id = tree.id
+				)
+				select exists (select 1 from tree where id = $2)`
+
+	var cycle bool
+	if err := q.QueryRow(ctx, query, taskID, *parentID).Scan(&cycle); err != nil {
+		return err
+	}
+	if cycle {
+		return apperror.ErrDataNotValid
+	}
+
+	return nil
+}
+
+func equalID(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 func isEmpty(value interface{}) bool {
@@ -91,131 +476,446 @@ func isEmpty(value interface{}) bool {
 		return v == ""
 	case time.Time:
 		return v.IsZero()
+	case *time.Time:
+		return v == nil
+	case bool:
+		return false
 	default:
 		return true
 	}
 }
 
 func (t *taskRepository) Create(ctx context.Context, task *entity.Task) (*entity.Task, error) {
-	query := `insert into task (id_user,header,description,start_date) values ($1,$2,$3,$4) returning *`
+	query := `insert into task (id_user,header,description,start_date,priority,due_date,due_has_time,project_id,parent_id,
+					rrule,series_id,occurrence,position)
+				values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) returning ` + taskColumns
 
-	row := t.Pool.QueryRow(ctx, query, task.UserID, task.Header, task.Description, task.StartDate)
-	return t.collectRow(row)
-}
+	occurrence := task.Occurrence
+	if occurrence == 0 {
+		occurrence = 1
+	}
+
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Следующее повторение серии остается в проекте серии, даже если проект уже в архиве
+	if err := t.checkProject(ctx, tx, task.ProjectID, task.UserID, task.SeriesID != nil); err != nil {
+		return nil, err
+	}
+	if err := t.checkParent(ctx, tx, task.ParentID, task.UserID); err != nil {
+		return nil, err
+	}
 
-func (t *taskRepository) GetByUserID(ctx context.Context, id string) ([]entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done from task
-				where id_user = $1`
+	// Новая задача встает в начало ручного порядка
+	var first string
+	if err := tx.QueryRow(ctx, `select coalesce(min(position), '') from task where id_user = $1`, task.UserID).Scan(&first); err != nil {
+		return nil, err
+	}
+	position, err := lexorank.Before(first)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := t.Pool.Query(ctx, query, id)
+	row := tx.QueryRow(ctx, query, task.UserID, task.Header, task.Description, task.StartDate, task.Priority,
+		task.DueDate, task.DueHasTime, task.ProjectID, task.ParentID, task.RRule, task.SeriesID, occurrence, position)
+	createdTask, err := t.collectRow(row)
 	if err != nil {
 		return nil, err
 	}
 
-	return t.collectRows(rows)
-}
+	// Первая задача серии повторений становится ее корнем
+	if task.RRule != "" && task.SeriesID == nil {
+		if _, err := tx.Exec(ctx, `update task set series_id = id where id = $1`, createdTask.ID); err != nil {
+			return nil, err
+		}
+	}
 
-func (t *taskRepository) GetByUserIDWithOffset(ctx context.Context, id string, offset int) ([]entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done from task
-				where id_user = $1
-				order by id desc
-				offset $2
-				limit 3`
+	if err := t.setTags(ctx, tx, createdTask.ID, task.UserID, task.TagIDs); err != nil {
+		return nil, err
+	}
 
-	rows, err := t.Pool.Query(ctx, query, id, offset)
+	createdTask, err = t.queryRow(ctx, tx, `select `+taskColumns+` from task where id = $1`, createdTask.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return t.collectRows(rows)
+	return createdTask, tx.Commit(ctx)
 }
 
 func (t *taskRepository) GetAll(ctx context.Context) ([]entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done from task`
+	query := `select ` + taskColumns + ` from task where deleted_at is null order by id`
+
+	return t.query(ctx, t.Conn(ctx), query)
+}
+
+// DeleteByID переносит задачу со всеми ее подзадачами в корзину, отметка времени у всего поддерева общая.
+// Возвращает id всех перенесенных задач
+func (t *taskRepository) DeleteByID(ctx context.Context, id int, deletedAt time.Time) ([]int, error) {
+	query := `with recursive tree as (
+					select id from task where id = $1 and deleted_at is null
+					union all
+					select c.id from task c join tree on c.parent_id = tree.id where c.deleted_at is null
+				)
+				update task set deleted_at = $2, version = version + 1 where id in (select id from tree) returning id`
+
+	return t.queryIDs(ctx, t.Conn(ctx), query, id, deletedAt)
+}
+
+// Restore возвращает задачу пользователя из корзины вместе с подзадачами, удаленными одновременно с ней,
+// и удаленными родительскими задачами, чтобы восстановленная задача не осталась внутри корзины.
+// Возвращает задачу и id всех восстановленных задач
+func (t *taskRepository) Restore(ctx context.Context, id int, userID string) (*entity.Task, []int, error) {
+	query := `with recursive target as (
+					select id, parent_id, deleted_at from task where id = $1 and id_user = $2 and deleted_at is not null
+				), down as (
+					select id from target
+					union all
+					select c.id from task c join down on c.parent_id = down.id join target on c.deleted_at = target.deleted_at
+				), up as (
+					select parent_id as id from target
+					union all
+					select p.parent_id from task p join up on p.id = up.id where p.deleted_at is not null
+				)
+				update task set deleted_at = null, version = version + 1
+				where deleted_at is not null and id in (select id from down union select id from up) returning id`
+
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ids, err := t.queryIDs(ctx, tx, query, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil, apperror.ErrNoRows
+	}
+
+	task, err := t.queryRow(ctx, tx, `select `+taskColumns+` from task where id = $1`, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, ids, tx.Commit(ctx)
+}
 
-	rows, err := t.Pool.Query(ctx, query)
+// DeletePermanently удаляет задачу пользователя без возможности восстановления, подзадачи удаляются вместе с ней.
+// Возвращает id всех удаленных задач
+func (t *taskRepository) DeletePermanently(ctx context.Context, id int, userID string) ([]int, error) {
+	query := `with recursive tree as (
+					select id from task where id = $1 and id_user = $2
+					union all
+					select c.id from task c join tree on c.parent_id = tree.id
+				)
+				delete from task where id in (select id from tree) returning id`
+
+	ids, err := t.queryIDs(ctx, t.Conn(ctx), query, id, userID)
 	if err != nil {
 		return nil, err
 	}
-	return t.collectRows(rows)
+	if len(ids) == 0 {
+		return nil, apperror.ErrNoRows
+	}
+	return ids, nil
 }
 
-func (t *taskRepository) DeleteByID(ctx context.Context, id int) error {
-	query := `delete from task where id = $1`
+// Purge окончательно удаляет задачи, попавшие в корзину раньше before, и возвращает их количество
+func (t *taskRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	query := `delete from task where deleted_at < $1`
 
-	_, err := t.Pool.Exec(ctx, query, id)
-	return err
+	tag, err := t.Conn(ctx).Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
 }
 
-func (t *taskRepository) GetByStatusWithOffset(ctx context.Context, userID string, status bool, offset int) ([]entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done
-				from task
-				where id_user = $1 and done = $2
-				order by id desc
-				offset $3
-				limit 3`
+func (t *taskRepository) GetByID(ctx context.Context, id int) (*entity.Task, error) {
+	query := `select ` + taskColumns + ` from task where id = $1 and deleted_at is null`
+
+	return t.queryRow(ctx, t.Conn(ctx), query, id)
+}
+
+// keepStatus оставляет задаче колонку доски, только если колонка согласуется с новым значением done
+const keepStatus = `status_id = case when $1 = coalesce((select s.done from status s where s.id = task.status_id), $1)
+					then status_id end`
 
-	rows, err := t.Pool.Query(ctx, query, userID, status, offset)
+// matchVersion пропускает обновление, если задана ожидаемая версия $3 и она не совпадает с текущей
+const matchVersion = `($3 = 0 or version = $3)`
+
+// UpdateDone меняет статус задачи, version — ожидаемая текущая версия задачи, 0 отключает проверку
+func (t *taskRepository) UpdateDone(ctx context.Context, status bool, taskID int, version int) (*entity.Task, error) {
+	query := `update task set done = $1, ` + keepStatus + `, version = version + 1
+				where id = $2 and deleted_at is null and ` + matchVersion + ` returning ` + taskColumns
+
+	return t.queryRow(ctx, t.Conn(ctx), query, status, taskID, version)
+}
+
+// UpdateDoneWithChildren меняет статус задачи вместе со всеми ее подзадачами на любой глубине.
+// Возвращает задачу и id всех измененных задач
+func (t *taskRepository) UpdateDoneWithChildren(ctx context.Context, status bool, taskID int, version int) (*entity.Task, []int, error) {
+	query := `with recursive tree as (
+					select id from task where id = $2 and deleted_at is null and ` + matchVersion + `
+					union all
+					select c.id from task c join tree on c.parent_id = tree.id where c.deleted_at is null
+				)
+				update task set done = $1, ` + keepStatus + `, version = version + 1 where id in (select id from tree) returning id`
+
+	tx, err := t.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ids, err := t.queryIDs(ctx, tx, query, status, taskID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil, apperror.ErrNoRows
 	}
 
-	return t.collectRows(rows)
+	task, err := t.queryRow(ctx, tx, `select `+taskColumns+` from task where id = $1`, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, ids, tx.Commit(ctx)
 }
 
-func (t *taskRepository) GetByDateAndStatusWithOffset(ctx context.Context, userID string, date time.Time, status bool, offset int) ([]entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done
-				from task
-				where id_user = $1 and done = $2 and start_date = $3
-				order by id desc
-				offset $4
-				limit 3`
+// UpdateStatus переносит задачу в колонку statusID, done задачи берется из колонки
+func (t *taskRepository) UpdateStatus(ctx context.Context, taskID int, statusID int, done bool, version int) (*entity.Task, error) {
+	query := `update task set status_id = $1, done = $2, version = version + 1
+				where id = $3 and deleted_at is null and ($4 = 0 or version = $4) returning ` + taskColumns
+
+	return t.queryRow(ctx, t.Conn(ctx), query, statusID, done, taskID, version)
+}
 
-	rows, err := t.Pool.Query(ctx, query, userID, status, date, offset)
+// UpdatePosition ставит задачу в ручном порядке после afterID и перед beforeID.
+// Если задан один сосед, второй берется из текущего порядка, ключи остальных задач не меняются
+func (t *taskRepository) UpdatePosition(ctx context.Context, taskID int, userID string, afterID, beforeID *int) (*entity.Task, error) {
+	tx, err := t.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	return t.collectRows(rows)
-}
+	var low, high string
+	if afterID != nil {
+		if low, err = t.position(ctx, tx, *afterID, userID); err != nil {
+			return nil, err
+		}
+	}
+	if beforeID != nil {
+		if high, err = t.position(ctx, tx, *beforeID, userID); err != nil {
+			return nil, err
+		}
+	}
 
-func (t *taskRepository) GetByDateAndStatus(ctx context.Context, userID string, date time.Time, status bool) ([]entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done
-				from task
-				where id_user = $1 and done = $2 and start_date = $3`
+	switch {
+	case beforeID == nil:
+		query := `select coalesce(min(position), '') from task where id_user = $1 and id <> $2 and position > $3 and deleted_at is null`
+		err = tx.QueryRow(ctx, query, userID, taskID, low).Scan(&high)
+	case afterID == nil:
+		query := `select coalesce(max(position), '') from task where id_user = $1 and id <> $2 and position < $3 and deleted_at is null`
+		err = tx.QueryRow(ctx, query, userID, taskID, high).Scan(&low)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := lexorank.Between(low, high)
+	if err != nil {
+		return nil, apperror.ErrDataNotValid
+	}
 
-	rows, err := t.Pool.Query(ctx, query, userID, status, date)
+	query := `update task set position = $1, version = version + 1 where id = $2 and id_user = $3 and deleted_at is null
+			returning ` + taskColumns
+	task, err := t.queryRow(ctx, tx, query, position, taskID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return t.collectRows(rows)
+	return task, tx.Commit(ctx)
 }
 
-func (t *taskRepository) GetByID(ctx context.Context, id int) (*entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done
-				from task where id = $1`
+// Bulk выполняет групповую операцию в одной транзакции. Операция применяется только к задачам владельца
+// bulk.UserID, для остальных задач возвращается статус BulkNotFound или BulkForbidden. Результаты идут в порядке bulk.IDs.
+// cascaded — id подзадач, измененных вместе с задачами из bulk.IDs
+func (t *taskRepository) Bulk(ctx context.Context, bulk *entity.Bulk) (results []entity.BulkResult, cascaded []int, err error) {
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Строки блокируются в порядке id, чтобы параллельные групповые операции не ждали друг друга по кругу
+	found, err := t.query(ctx, tx, `select `+taskColumns+` from task where id = any($1) and deleted_at is null
+				order by id for update`, bulk.IDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	before := make(map[int]*entity.Task, len(found))
+	ids := make([]int, 0, len(found))
+	for i := range found {
+		before[found[i].ID] = &found[i]
+		if found[i].UserID == bulk.UserID {
+			ids = append(ids, found[i].ID)
+		}
+	}
+
+	if len(ids) > 0 {
+		changed, err := t.bulkApply(ctx, tx, bulk, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, id := range changed {
+			if _, ok := before[id]; !ok {
+				cascaded = append(cascaded, id)
+			}
+		}
+	}
+
+	after := make(map[int]*entity.Task, len(ids))
+	if bulk.Action != entity.BulkDelete && len(ids) > 0 {
+		tasks, err := t.query(ctx, tx, `select `+taskColumns+` from task where id = any($1)`, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range tasks {
+			after[tasks[i].ID] = &tasks[i]
+		}
+	}
 
-	row := t.Pool.QueryRow(ctx, query, id)
-	return t.collectRow(row)
+	results = make([]entity.BulkResult, 0, len(bulk.IDs))
+	for _, id := range bulk.IDs {
+		task, ok := before[id]
+		switch {
+		case !ok:
+			results = append(results, entity.BulkResult{ID: id, Status: entity.BulkNotFound})
+		case task.UserID != bulk.UserID:
+			results = append(results, entity.BulkResult{ID: id, Status: entity.BulkForbidden})
+		default:
+			results = append(results, entity.BulkResult{ID: id, Status: entity.BulkOK, Task: after[id], Before: task})
+		}
+	}
+
+	return results, cascaded, tx.Commit(ctx)
 }
 
-func (t *taskRepository) UpdateDone(ctx context.Context, status bool, taskID int) (*entity.Task, error) {
-	query := `update task set done = $1 where id = $2 returning *`
+// bulkApply изменяет задачи ids, все они уже заблокированы и принадлежат владельцу операции.
+// Возвращает id всех измененных задач, включая подзадачи, удаленные вместе с ними
+func (t *taskRepository) bulkApply(ctx context.Context, q postgres.Querier, bulk *entity.Bulk, ids []int) ([]int, error) {
+	switch bulk.Action {
+	case entity.BulkComplete, entity.BulkReopen:
+		query := `update task set done = $1, ` + keepStatus + `, version = version + 1 where id = any($2)`
+
+		_, err := q.Exec(ctx, query, bulk.Action == entity.BulkComplete, ids)
+		return ids, err
+	case entity.BulkDelete:
+		query := `with recursive tree as (
+						select id from task where id = any($1)
+						union all
+						select c.id from task c join tree on c.parent_id = tree.id where c.deleted_at is null
+					)
+					update task set deleted_at = $2, version = version + 1 where id in (select id from tree) returning id`
+
+		return t.queryIDs(ctx, q, query, ids, bulk.At)
+	case entity.BulkAddTags, entity.BulkRemoveTags:
+		if err := t.checkTags(ctx, q, bulk.TagIDs, bulk.UserID); err != nil {
+			return nil, err
+		}
+
+		query := `insert into task_tag (task_id, tag_id)
+					select task_id, tag_id from unnest($1::int[]) task_id cross join unnest($2::int[]) tag_id
+					on conflict do nothing`
+		if bulk.Action == entity.BulkRemoveTags {
+			query = `delete from task_tag where task_id = any($1) and tag_id = any($2)`
+		}
+		if _, err := q.Exec(ctx, query, ids, bulk.TagIDs); err != nil {
+			return nil, err
+		}
 
-	row := t.Pool.QueryRow(ctx, query, status, taskID)
-	return t.collectRow(row)
+		_, err := q.Exec(ctx, `update task set version = version + 1 where id = any($1)`, ids)
+		return ids, err
+	case entity.BulkMove:
+		if err := t.checkProject(ctx, q, bulk.ProjectID, bulk.UserID, false); err != nil {
+			return nil, err
+		}
+
+		_, err := q.Exec(ctx, `update task set project_id = $1, version = version + 1 where id = any($2)`, bulk.ProjectID, ids)
+		return ids, err
+	}
+
+	return nil, apperror.ErrDataNotValid
 }
 
-func (t *taskRepository) GetByStatus(ctx context.Context, userID string, status bool) ([]entity.Task, error) {
-	query := `select id, id_user, header, description, created_at, start_date, done
-				from task
-				where id_user = $1 and done = $2`
+// checkTags проверяет, что все метки принадлежат пользователю
+func (t *taskRepository) checkTags(ctx context.Context, q postgres.Querier, tagIDs []int, userID string) error {
+	query := `select count(*) from tag where id = any($1) and id_user = $2`
+
+	unique := make(map[int]struct{}, len(tagIDs))
+	for _, id := range tagIDs {
+		unique[id] = struct{}{}
+	}
+
+	var count int
+	if err := q.QueryRow(ctx, query, tagIDs, userID).Scan(&count); err != nil {
+		return err
+	}
+	if count != len(unique) {
+		return apperror.ErrDataNotValid
+	}
+
+	return nil
+}
+
+// position возвращает ключ ручного порядка задачи пользователя
+func (t *taskRepository) position(ctx context.Context, q postgres.Querier, taskID int, userID string) (string, error) {
+	var position string
+	err := q.QueryRow(ctx, `select position from task where id = $1 and id_user = $2 and deleted_at is null`, taskID, userID).Scan(&position)
+	if err == pgx.ErrNoRows {
+		return "", apperror.ErrDataNotValid
+	}
+	return position, err
+}
+
+func (t *taskRepository) GetByParentID(ctx context.Context, parentID int) ([]entity.Task, error) {
+	query := `select ` + taskColumns + ` from task where parent_id = $1 and deleted_at is null order by id`
+
+	return t.query(ctx, t.Conn(ctx), query, parentID)
+}
+
+// GetByStatusID возвращает задачи колонки и блокирует их до конца транзакции
+func (t *taskRepository) GetByStatusID(ctx context.Context, statusID int) ([]entity.Task, error) {
+	query := `select ` + taskColumns + ` from task where status_id = $1 and deleted_at is null order by id for update`
+
+	return t.query(ctx, t.Conn(ctx), query, statusID)
+}
+
+func (t *taskRepository) UpdateProject(ctx context.Context, taskID int, userID string, projectID *int) (*entity.Task, error) {
+	query := `update task set project_id = $1, version = version + 1 where id = $2 and deleted_at is null returning ` + taskColumns
+
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := t.checkProject(ctx, tx, projectID, userID, false); err != nil {
+		return nil, err
+	}
 
-	rows, err := t.Pool.Query(ctx, query, userID, status)
+	task, err := t.queryRow(ctx, tx, query, projectID, taskID)
 	if err != nil {
 		return nil, err
 	}
 
-	return t.collectRows(rows)
+	return task, tx.Commit(ctx)
 }
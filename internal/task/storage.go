@@ -8,16 +8,24 @@ import (
 
 //go:generate mockgen -source storage.go -destination mock/pg_repository_mock.go -package mock
 type TaskRepository interface {
-	GetByDateAndStatusWithOffset(ctx context.Context, userID string, date time.Time, status bool, offset int) ([]entity.Task, error)
-	GetByDateAndStatus(ctx context.Context, userID string, date time.Time, status bool) ([]entity.Task, error)
-	GetByStatusWithOffset(ctx context.Context, userID string, status bool, offset int) ([]entity.Task, error)
-	GetByUserIDWithOffset(ctx context.Context, id string, offset int) ([]entity.Task, error)
-	GetByStatus(ctx context.Context, userID string, status bool) ([]entity.Task, error)
-	UpdateDone(ctx context.Context, status bool, taskID int) (*entity.Task, error)
+	UpdateDone(ctx context.Context, status bool, taskID int, version int) (*entity.Task, error)
+	UpdateDoneWithChildren(ctx context.Context, status bool, taskID int, version int) (*entity.Task, []int, error)
+	UpdateStatus(ctx context.Context, taskID int, statusID int, done bool, version int) (*entity.Task, error)
+	UpdatePosition(ctx context.Context, taskID int, userID string, afterID, beforeID *int) (*entity.Task, error)
+	UpdateProject(ctx context.Context, taskID int, userID string, projectID *int) (*entity.Task, error)
 	Update(ctx context.Context, task *entity.Task) (*entity.Task, error)
+	Replace(ctx context.Context, task *entity.Task) (*entity.Task, error)
+	UpdateSeries(ctx context.Context, seriesID int, task *entity.Task) (*entity.Task, error)
 	Create(ctx context.Context, task *entity.Task) (*entity.Task, error)
-	GetByUserID(ctx context.Context, id string) ([]entity.Task, error)
+	Find(ctx context.Context, query *entity.TaskQuery) (tasks []entity.Task, total int, more bool, err error)
+	Search(ctx context.Context, query *entity.TaskQuery) ([]entity.SearchResult, int, error)
 	GetByID(ctx context.Context, id int) (*entity.Task, error)
+	GetByParentID(ctx context.Context, parentID int) ([]entity.Task, error)
+	GetByStatusID(ctx context.Context, statusID int) ([]entity.Task, error)
 	GetAll(ctx context.Context) ([]entity.Task, error)
-	DeleteByID(ctx context.Context, id int) error
+	DeleteByID(ctx context.Context, id int, deletedAt time.Time) ([]int, error)
+	Restore(ctx context.Context, id int, userID string) (*entity.Task, []int, error)
+	DeletePermanently(ctx context.Context, id int, userID string) ([]int, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	Bulk(ctx context.Context, bulk *entity.Bulk) (results []entity.BulkResult, cascaded []int, err error)
 }
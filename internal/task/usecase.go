@@ -6,12 +6,25 @@ import (
 )
 
 type TaskUsecase interface {
-	GetTask(ctx context.Context, userID string, option *entity.ParamOption) ([]entity.Task, error)
+	GetTask(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error)
 	CreateTask(ctx context.Context, task *entity.Task) (*entity.Task, error)
 	UpdateTask(ctx context.Context, task *entity.Task) (*entity.Task, error)
+	UpdateTaskSeries(ctx context.Context, task *entity.Task) (*entity.Task, error)
+	PatchTask(ctx context.Context, task *entity.Task) (*entity.Task, error)
 	DeleteTask(ctx context.Context, id int) error
+	RestoreTask(ctx context.Context, id int, userID string) (*entity.Task, error)
+	DeleteTaskPermanently(ctx context.Context, id int, userID string) error
 	GetAllTasks(ctx context.Context) ([]entity.Task, error)
-	GetUserTasks(ctx context.Context, userID string, option *entity.ParamOption) ([]entity.Task, error)
+	SearchTasks(ctx context.Context, query *entity.TaskQuery) (*entity.SearchPage, error)
+	GetUserTasks(ctx context.Context, query *entity.TaskQuery) ([]entity.Task, error)
 	IsEqualUserID(ctx context.Context, contextUserID string, taskID int) (bool, error)
-	UpdateTaskStatus(ctx context.Context, status bool, taskID int) (*entity.Task, error)
+	UpdateTaskStatus(ctx context.Context, status bool, taskID int, cascade bool, version int) (*entity.Task, error)
+	MoveTaskToStatus(ctx context.Context, taskID int, userID string, name string, version int) (*entity.Task, error)
+	UpdateColumn(ctx context.Context, column *entity.Status, done *bool) (*entity.Status, error)
+	GetBoard(ctx context.Context, query *entity.TaskQuery) (*entity.Board, error)
+	ReorderTask(ctx context.Context, taskID int, userID string, afterID, beforeID *int) (*entity.Task, error)
+	GetTaskByID(ctx context.Context, id int) (*entity.Task, error)
+	GetSubtasks(ctx context.Context, parentID int) ([]entity.Task, error)
+	MoveTask(ctx context.Context, taskID int, userID string, projectID *int) (*entity.Task, error)
+	BulkTasks(ctx context.Context, bulk *entity.Bulk) (*entity.BulkReport, error)
 }
@@ -2,78 +2,475 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/audit"
 	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/status"
 	"go-todolist-sber/internal/task"
+	"go-todolist-sber/pkg/postgres"
+	"go-todolist-sber/pkg/rrule"
+	"strings"
+	"time"
 )
 
+const maxSearchQueryLength = 200
+
+// maxBulkSize ограничивает число задач в одной групповой операции
+const maxBulkSize = 500
+
 type taskUsecase struct {
-	taskRepo task.TaskRepository
+	taskRepo      task.TaskRepository
+	auditRepo     audit.AuditRepository
+	statusUsecase status.StatusUsecase
+	// tx объединяет изменение задачи и запись журнала в одну транзакцию
+	tx  postgres.Transactor
+	now func() time.Time
 }
 
-func NewTaskUsecase(taskRepo task.TaskRepository) task.TaskUsecase {
+func NewTaskUsecase(taskRepo task.TaskRepository, auditRepo audit.AuditRepository, statusUsecase status.StatusUsecase, tx postgres.Transactor) task.TaskUsecase {
 	return &taskUsecase{
-		taskRepo: taskRepo,
+		taskRepo:      taskRepo,
+		auditRepo:     auditRepo,
+		statusUsecase: statusUsecase,
+		tx:            tx,
+		now:           time.Now,
+	}
+}
+
+// record пишет изменение задачи taskID пользователя userID в журнал от имени автора из ctx.
+// before и after — состояние задачи до и после изменения. Вызывается внутри t.tx вместе с самим изменением,
+// чтобы изменение без записи в журнале не сохранилось
+func (t *taskUsecase) record(ctx context.Context, action entity.AuditAction, taskID int, userID string, before, after *entity.Task) error {
+	actor := entity.ActorFromContext(ctx)
+
+	return t.auditRepo.Create(ctx, &entity.AuditEntry{
+		TaskID:    taskID,
+		Action:    action,
+		UserID:    userID,
+		ActorID:   actor.UserID,
+		Before:    before,
+		After:     after,
+		RequestID: actor.RequestID,
+		CreatedAt: entity.WallClock(t.now()),
+	})
+}
+
+// recordCascade пишет в журнал задачи ids, измененные вместе с задачей rootID, по записи на задачу.
+// Сама rootID пропускается: ее записывает вызывающий вместе с состоянием до и после
+func (t *taskUsecase) recordCascade(ctx context.Context, action entity.AuditAction, rootID int, userID string, ids []int) error {
+	for _, id := range ids {
+		if id == rootID {
+			continue
+		}
+		if err := t.record(ctx, action, id, userID, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markOverdue вычисляет признак просрочки, чтобы клиентам не приходилось делать это самим
+func (t *taskUsecase) markOverdue(task *entity.Task) {
+	if task != nil {
+		task.Overdue = task.IsOverdue(entity.WallClock(t.now()))
+	}
+}
+
+func (t *taskUsecase) markOverdueList(tasks []entity.Task) []entity.Task {
+	for i := range tasks {
+		t.markOverdue(&tasks[i])
+	}
+	return tasks
+}
+
+// checkVersion сверяет ожидаемую версию задачи с текущей, нулевая версия проверку отключает
+func checkVersion(current *entity.Task, version int) error {
+	if version != 0 && current.Version != version {
+		return apperror.ErrVersionMismatch
+	}
+	return nil
+}
+
+// versionError переводит отсутствие обновленной строки в конфликт версий: задача была прочитана,
+// значит, ее успели изменить между чтением и обновлением
+func versionError(err error, version int) error {
+	if version != 0 && errors.Is(err, apperror.ErrNoRows) {
+		return apperror.ErrVersionMismatch
+	}
+	return err
+}
+
+// normalizeRRule проверяет правило повторения и приводит его к каноническому виду
+func normalizeRRule(task *entity.Task) error {
+	if task.RRule == "" {
+		return nil
+	}
+
+	rule, err := rrule.Parse(task.RRule)
+	if err != nil {
+		return apperror.ErrDataNotValid
+	}
+	task.RRule = rule.String()
+
+	return nil
+}
+
+// spawnNext создает следующее повторение завершенной повторяющейся задачи. Вызывается в транзакции завершения,
+// чтобы ошибка создания отменила и само завершение.
+// Повторное завершение того же повторения не создает дубликат: номер повторения в серии уникален
+func (t *taskUsecase) spawnNext(ctx context.Context, task *entity.Task) error {
+	rule, err := rrule.Parse(task.RRule)
+	if err != nil {
+		return err
+	}
+
+	next, ok := rule.Next(task.StartDate, task.StartDate, task.Occurrence)
+	if !ok {
+		return nil
+	}
+
+	seriesID := task.SeriesID
+	if seriesID == nil {
+		seriesID = &task.ID
 	}
+
+	nextTask := &entity.Task{
+		UserID:      task.UserID,
+		Header:      task.Header,
+		Description: task.Description,
+		StartDate:   next,
+		Priority:    task.Priority,
+		DueHasTime:  task.DueHasTime,
+		ProjectID:   task.ProjectID,
+		ParentID:    task.ParentID,
+		RRule:       task.RRule,
+		SeriesID:    seriesID,
+		Occurrence:  task.Occurrence + 1,
+		TagIDs:      make([]int, 0, len(task.Tags)),
+	}
+	if task.DueDate != nil {
+		dueDate := task.DueDate.Add(next.Sub(task.StartDate))
+		nextTask.DueDate = &dueDate
+	}
+	for _, tag := range task.Tags {
+		nextTask.TagIDs = append(nextTask.TagIDs, tag.ID)
+	}
+
+	return t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		created, err := t.taskRepo.Create(ctx, nextTask)
+		if errors.Is(err, apperror.ErrUniqueViolation) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return t.record(ctx, entity.AuditCreate, created.ID, created.UserID, nil, created)
+	})
 }
 
 func (t *taskUsecase) CreateTask(ctx context.Context, task *entity.Task) (*entity.Task, error) {
-	task, err := t.taskRepo.Create(ctx, task)
+	if task.Priority == "" {
+		task.Priority = entity.PriorityNone
+	}
+	if !entity.IsPriorityValid(task.Priority) {
+		return nil, apperror.ErrDataNotValid
+	}
+	if err := normalizeRRule(task); err != nil {
+		return nil, err
+	}
+
+	err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.taskRepo.Create(ctx, task); err != nil {
+			return err
+		}
+		return t.record(ctx, entity.AuditCreate, task.ID, task.UserID, nil, task)
+	})
 	if err != nil {
 		return nil, err
 	}
+
+	t.markOverdue(task)
 	return task, nil
 }
 
 func (t *taskUsecase) UpdateTask(ctx context.Context, task *entity.Task) (*entity.Task, error) {
-	task, err := t.taskRepo.Update(ctx, task)
+	current, err := t.taskRepo.GetByID(ctx, task.ID)
 	if err != nil {
 		return nil, err
 	}
+
+	return t.update(ctx, current, task)
+}
+
+// update изменяет задачу, текущее состояние которой current
+func (t *taskUsecase) update(ctx context.Context, current, task *entity.Task) (*entity.Task, error) {
+	if task.Priority != "" && !entity.IsPriorityValid(task.Priority) {
+		return nil, apperror.ErrDataNotValid
+	}
+	if err := normalizeRRule(task); err != nil {
+		return nil, err
+	}
+	if err := checkVersion(current, task.Version); err != nil {
+		return nil, err
+	}
+
+	version := task.Version
+	err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.taskRepo.Update(ctx, task); err != nil {
+			return versionError(err, version)
+		}
+		return t.record(ctx, entity.AuditUpdate, task.ID, task.UserID, current, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.markOverdue(task)
 	return task, nil
 }
 
-func (t *taskUsecase) DeleteTask(ctx context.Context, id int) error {
-	if err := t.taskRepo.DeleteByID(ctx, id); err != nil {
-		return err
+// UpdateTaskSeries изменяет задачу и все незавершенные повторения ее серии.
+// Для задачи вне серии работает так же, как UpdateTask
+func (t *taskUsecase) UpdateTaskSeries(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	current, err := t.taskRepo.GetByID(ctx, task.ID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	if current.SeriesID == nil {
+		return t.update(ctx, current, task)
+	}
+
+	if task.Priority != "" && !entity.IsPriorityValid(task.Priority) {
+		return nil, apperror.ErrDataNotValid
+	}
+	if err := normalizeRRule(task); err != nil {
+		return nil, err
+	}
+	if err := checkVersion(current, task.Version); err != nil {
+		return nil, err
+	}
+
+	version := task.Version
+	err = t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.taskRepo.UpdateSeries(ctx, *current.SeriesID, task); err != nil {
+			return versionError(err, version)
+		}
+		return t.record(ctx, entity.AuditUpdate, task.ID, task.UserID, current, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.markOverdue(task)
+	return task, nil
 }
 
-func (t *taskUsecase) GetAllTasks(ctx context.Context) ([]entity.Task, error) {
-	tasks, err := t.taskRepo.GetAll(ctx)
+// PatchTask записывает задачу целиком: task — состояние задачи после применения патча.
+// Поля, которые патч сбросил, сбрасываются и в хранилище. Завершение повторяющейся задачи создает следующее повторение
+func (t *taskUsecase) PatchTask(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	current, err := t.taskRepo.GetByID(ctx, task.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return tasks, nil
+	if !entity.IsPriorityValid(task.Priority) {
+		return nil, apperror.ErrDataNotValid
+	}
+	if task.ParentID != nil && *task.ParentID == task.ID {
+		return nil, apperror.ErrDataNotValid
+	}
+	if err := normalizeRRule(task); err != nil {
+		return nil, err
+	}
+	if err := checkVersion(current, task.Version); err != nil {
+		return nil, err
+	}
+
+	version := task.Version
+	err = t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.taskRepo.Replace(ctx, task); err != nil {
+			return versionError(err, version)
+		}
+		if err := t.record(ctx, entity.AuditUpdate, task.ID, task.UserID, current, task); err != nil {
+			return err
+		}
+
+		if task.Done && !current.Done && task.RRule != "" {
+			return t.spawnNext(ctx, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.markOverdue(task)
+	return task, nil
 }
 
-func (t *taskUsecase) GetUserTasks(ctx context.Context, userID string, option *entity.ParamOption) ([]entity.Task, error) {
-	if option.Status != nil && !option.DateTime.IsZero() {
-		tasks, err := t.taskRepo.GetByDateAndStatus(ctx, userID, option.DateTime, *option.Status)
+// DeleteTask переносит задачу вместе с подзадачами в корзину
+func (t *taskUsecase) DeleteTask(ctx context.Context, id int) error {
+	current, err := t.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ids, err := t.taskRepo.DeleteByID(ctx, id, entity.WallClock(t.now()))
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := t.record(ctx, entity.AuditDelete, id, current.UserID, current, nil); err != nil {
+			return err
 		}
+		return t.recordCascade(ctx, entity.AuditDelete, id, current.UserID, ids)
+	})
+}
 
-		if tasks == nil || len(tasks) == 0 {
-			return nil, apperror.ErrNoRows
+// RestoreTask возвращает задачу из корзины вместе с подзадачами, удаленными одновременно с ней,
+// и удаленными родительскими задачами
+func (t *taskUsecase) RestoreTask(ctx context.Context, id int, userID string) (*entity.Task, error) {
+	var task *entity.Task
+	err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var ids []int
+		var err error
+		if task, ids, err = t.taskRepo.Restore(ctx, id, userID); err != nil {
+			return err
+		}
+		if err := t.record(ctx, entity.AuditRestore, id, userID, nil, task); err != nil {
+			return err
 		}
+		return t.recordCascade(ctx, entity.AuditRestore, id, userID, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		return tasks, nil
-	} else {
+	t.markOverdue(task)
+	return task, nil
+}
 
-		tasks, err := t.taskRepo.GetByUserID(ctx, userID)
+// DeleteTaskPermanently удаляет задачу пользователя вместе с подзадачами без возможности восстановления
+func (t *taskUsecase) DeleteTaskPermanently(ctx context.Context, id int, userID string) error {
+	return t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ids, err := t.taskRepo.DeletePermanently(ctx, id, userID)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := t.record(ctx, entity.AuditPurge, id, userID, nil, nil); err != nil {
+			return err
 		}
+		return t.recordCascade(ctx, entity.AuditPurge, id, userID, ids)
+	})
+}
 
-		if tasks == nil || len(tasks) == 0 {
-			return nil, apperror.ErrNoRows
+func (t *taskUsecase) GetAllTasks(ctx context.Context) ([]entity.Task, error) {
+	tasks, err := t.taskRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.markOverdueList(tasks), nil
+}
+
+func (t *taskUsecase) GetUserTasks(ctx context.Context, query *entity.TaskQuery) ([]entity.Task, error) {
+	if query.Filter.Due != "" {
+		query.Filter.Now = t.now()
+	}
+
+	tasks, _, _, err := t.taskRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, apperror.ErrNoRows
+	}
+
+	return t.markOverdueList(tasks), nil
+}
+
+// getTaskByCursor собирает страницу задач в режиме курсорной пагинации.
+// Курсоры указывают на первую и последнюю задачу страницы и передаются обратно в before и after
+func (t *taskUsecase) getTaskByCursor(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error) {
+	cursor, before := query.After, false
+	if query.Before != nil {
+		cursor, before = query.Before, true
+	}
+	if cursor != nil && !cursor.Matches(query.Sort) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	tasks, total, more, err := t.taskRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := entity.NewTaskPage(t.markOverdueList(tasks), total, 0, query.PageSize)
+	page.PageSize = query.PageSize
+	if len(tasks) == 0 {
+		return page, nil
+	}
+
+	first := entity.NewCursor(&tasks[0], query.Sort).Encode()
+	last := entity.NewCursor(&tasks[len(tasks)-1], query.Sort).Encode()
+	if before {
+		if more {
+			page.PrevCursor = first
 		}
+		page.NextCursor = last
+	} else {
+		if more {
+			page.NextCursor = last
+		}
+		if cursor != nil {
+			page.PrevCursor = first
+		}
+	}
+
+	return page, nil
+}
 
-		return tasks, nil
+// SearchTasks ищет задачи по query.Filter.Text, остальные условия query сужают выдачу
+func (t *taskUsecase) SearchTasks(ctx context.Context, query *entity.TaskQuery) (*entity.SearchPage, error) {
+	query.Filter.Text = strings.TrimSpace(query.Filter.Text)
+	if query.Filter.Text == "" {
+		return nil, apperror.ErrDataNotValid
+	}
+	if err := t.prepareQuery(query); err != nil {
+		return nil, err
 	}
+
+	results, total, err := t.taskRepo.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		t.markOverdue(&results[i].Task)
+	}
+	return entity.NewSearchPage(results, total, query.Page, query.PageSize), nil
+}
+
+// prepareQuery проверяет запрос и подставляет значения по умолчанию
+func (t *taskUsecase) prepareQuery(query *entity.TaskQuery) error {
+	if len(query.Filter.Text) > maxSearchQueryLength {
+		return apperror.ErrDataNotValid
+	}
+	if query.PageSize == 0 {
+		query.PageSize = entity.DefaultPageSize
+	}
+	if query.Filter.Due != "" {
+		query.Filter.Now = t.now()
+	}
+	return nil
 }
 
 func (t *taskUsecase) IsEqualUserID(ctx context.Context, contextUserID string, taskID int) (bool, error) {
@@ -89,60 +486,379 @@ func (t *taskUsecase) IsEqualUserID(ctx context.Context, contextUserID string, t
 	return true, nil
 }
 
-func (t *taskUsecase) UpdateTaskStatus(ctx context.Context, status bool, taskID int) (*entity.Task, error) {
-	task, err := t.taskRepo.UpdateDone(ctx, status, taskID)
+// UpdateTaskStatus меняет статус задачи, version — ожидаемая текущая версия задачи, 0 отключает проверку
+func (t *taskUsecase) UpdateTaskStatus(ctx context.Context, status bool, taskID int, cascade bool, version int) (*entity.Task, error) {
+	current, err := t.taskRepo.GetByID(ctx, taskID)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkVersion(current, version); err != nil {
+		return nil, err
+	}
+
+	var task *entity.Task
+	err = t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var ids []int
+		var err error
+		if cascade {
+			task, ids, err = t.taskRepo.UpdateDoneWithChildren(ctx, status, taskID, version)
+		} else {
+			task, err = t.taskRepo.UpdateDone(ctx, status, taskID, version)
+		}
+		if err != nil {
+			return versionError(err, version)
+		}
+		if err := t.record(ctx, entity.AuditStatus, task.ID, task.UserID, current, task); err != nil {
+			return err
+		}
+		if err := t.recordCascade(ctx, entity.AuditStatus, task.ID, task.UserID, ids); err != nil {
+			return err
+		}
 
+		if status && task.RRule != "" {
+			return t.spawnNext(ctx, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.markOverdue(task)
 	return task, nil
 }
 
-func (t *taskUsecase) GetTask(ctx context.Context, userID string, option *entity.ParamOption) ([]entity.Task, error) {
-	offset := (option.Page - 1) * 3
+// MoveTaskToStatus переносит задачу в колонку name с учетом разрешенных переходов.
+// Перенос в завершающую колонку завершает задачу и для повторяющейся задачи создает следующее повторение
+func (t *taskUsecase) MoveTaskToStatus(ctx context.Context, taskID int, userID string, name string, version int) (*entity.Task, error) {
+	current, err := t.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(current, version); err != nil {
+		return nil, err
+	}
 
-	switch {
-	case !option.DateTime.IsZero() && option.Status != nil && option.Page > 0: // Пагианция по статусу и времени
-		tasks, err := t.taskRepo.GetByDateAndStatusWithOffset(ctx, userID, option.DateTime, *option.Status, offset)
-		if err != nil {
-			return nil, err
+	statuses, err := t.statusUsecase.GetWorkflow(ctx, userID, current.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := entity.FindStatus(statuses, strings.TrimSpace(name))
+	if target == nil {
+		return nil, apperror.ErrDataNotValid
+	}
+	if column := entity.ColumnOf(statuses, current); column >= 0 && !statuses[column].CanMoveTo(target.ID) {
+		return nil, apperror.ErrTransitionNotAllowed
+	}
+
+	var task *entity.Task
+	err = t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.taskRepo.UpdateStatus(ctx, taskID, target.ID, target.Done, version); err != nil {
+			return versionError(err, version)
+		}
+		if err := t.record(ctx, entity.AuditStatus, task.ID, task.UserID, current, task); err != nil {
+			return err
 		}
 
-		return tasks, nil
-	case option.DateTime.IsZero() && option.Status != nil && option.Page > 0: // Пагинация по статусу
-		tasks, err := t.taskRepo.GetByStatusWithOffset(ctx, userID, *option.Status, offset)
-		if err != nil {
-			return nil, err
+		if target.Done && !current.Done && task.RRule != "" {
+			return t.spawnNext(ctx, task)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		return tasks, nil
-	case option.DateTime.IsZero() && option.Status == nil && option.Page > 0: // Просто пагинация
-		tasks, err := t.taskRepo.GetByUserIDWithOffset(ctx, userID, offset)
-		if err != nil {
-			return nil, err
+	t.markOverdue(task)
+	return task, nil
+}
+
+// UpdateColumn изменяет колонку доски, done == nil сохраняет текущий признак завершения.
+// Если признак меняется, задачи колонки завершаются или открываются по одной, как при MoveTaskToStatus:
+// каждое изменение попадает в журнал, а завершение повторяющейся задачи создает следующее повторение
+func (t *taskUsecase) UpdateColumn(ctx context.Context, column *entity.Status, done *bool) (*entity.Status, error) {
+	current, err := t.statusUsecase.GetStatus(ctx, column.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	column.Done = current.Done
+	if done != nil {
+		column.Done = *done
+	}
+
+	var updated *entity.Status
+	err = t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = t.statusUsecase.UpdateStatus(ctx, column); err != nil {
+			return err
+		}
+		if updated.Done == current.Done {
+			return nil
 		}
 
-		return tasks, nil
-	case !option.DateTime.IsZero() && option.Status != nil && option.Page == 0: // Без пагинации по статусу и времени
-		tasks, err := t.taskRepo.GetByDateAndStatus(ctx, userID, option.DateTime, *option.Status)
+		tasks, err := t.taskRepo.GetByStatusID(ctx, column.ID)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		for i := range tasks {
+			before := &tasks[i]
+			if before.Done == updated.Done {
+				continue
+			}
 
-		return tasks, nil
-	case option.DateTime.IsZero() && option.Status != nil && option.Page == 0: // Без пагинации по статусу
-		tasks, err := t.taskRepo.GetByStatus(ctx, userID, *option.Status)
-		if err != nil {
-			return nil, err
+			task, err := t.taskRepo.UpdateStatus(ctx, before.ID, column.ID, updated.Done, before.Version)
+			if err != nil {
+				return versionError(err, before.Version)
+			}
+			if err := t.record(ctx, entity.AuditStatus, task.ID, task.UserID, before, task); err != nil {
+				return err
+			}
+
+			if updated.Done && task.RRule != "" {
+				if err := t.spawnNext(ctx, task); err != nil {
+					return err
+				}
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		return tasks, nil
-	default: // Cписок всех тасок
-		tasks, err := t.taskRepo.GetByUserID(ctx, userID)
+	return updated, nil
+}
+
+// GetBoard возвращает задачи пользователя по query, разложенные по колонкам доски.
+// Внутри колонки задачи идут в порядке сортировки query
+func (t *taskUsecase) GetBoard(ctx context.Context, query *entity.TaskQuery) (*entity.Board, error) {
+	query.Filter.Text = strings.TrimSpace(query.Filter.Text)
+	if err := t.prepareQuery(query); err != nil {
+		return nil, err
+	}
+
+	statuses, err := t.statusUsecase.GetWorkflow(ctx, query.UserID, query.Filter.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, _, _, err := t.taskRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewBoard(statuses, t.markOverdueList(tasks)), nil
+}
+
+// ReorderTask переносит задачу в ручном порядке между соседями afterID и beforeID, достаточно одного соседа
+func (t *taskUsecase) ReorderTask(ctx context.Context, taskID int, userID string, afterID, beforeID *int) (*entity.Task, error) {
+	if afterID == nil && beforeID == nil {
+		return nil, apperror.ErrDataNotValid
+	}
+	if afterID != nil && *afterID == taskID || beforeID != nil && *beforeID == taskID {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	current, err := t.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *entity.Task
+	err = t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.taskRepo.UpdatePosition(ctx, taskID, userID, afterID, beforeID); err != nil {
+			return err
+		}
+		return t.record(ctx, entity.AuditUpdate, task.ID, task.UserID, current, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.markOverdue(task)
+	return task, nil
+}
+
+func (t *taskUsecase) GetTaskByID(ctx context.Context, id int) (*entity.Task, error) {
+	task, err := t.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	t.markOverdue(task)
+	return task, nil
+}
+
+func (t *taskUsecase) GetSubtasks(ctx context.Context, parentID int) ([]entity.Task, error) {
+	tasks, err := t.taskRepo.GetByParentID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.markOverdueList(tasks), nil
+}
+
+func (t *taskUsecase) MoveTask(ctx context.Context, taskID int, userID string, projectID *int) (*entity.Task, error) {
+	current, err := t.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *entity.Task
+	err = t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.taskRepo.UpdateProject(ctx, taskID, userID, projectID); err != nil {
+			return err
+		}
+		return t.record(ctx, entity.AuditUpdate, task.ID, task.UserID, current, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.markOverdue(task)
+	return task, nil
+}
+
+// BulkTasks применяет групповую операцию к задачам из bulk.IDs или, если список пуст, ко всем задачам
+// по фильтру bulk.Query. Изменения выполняются в одной транзакции, по каждой задаче возвращается свой результат
+func (t *taskUsecase) BulkTasks(ctx context.Context, bulk *entity.Bulk) (*entity.BulkReport, error) {
+	if !entity.IsBulkActionValid(bulk.Action) {
+		return nil, apperror.ErrDataNotValid
+	}
+	if (bulk.Action == entity.BulkAddTags || bulk.Action == entity.BulkRemoveTags) && len(bulk.TagIDs) == 0 {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	if len(bulk.IDs) == 0 {
+		ids, err := t.bulkQueryIDs(ctx, bulk.Query)
 		if err != nil {
 			return nil, err
 		}
+		bulk.IDs = ids
+	}
+	bulk.IDs = uniqueIDs(bulk.IDs)
+	if len(bulk.IDs) > maxBulkSize {
+		return nil, apperror.ErrDataNotValid
+	}
+	if len(bulk.IDs) == 0 {
+		return entity.NewBulkReport(bulk.Action, []entity.BulkResult{}), nil
+	}
 
-		return tasks, nil
+	action := entity.AuditUpdate
+	switch bulk.Action {
+	case entity.BulkComplete, entity.BulkReopen:
+		action = entity.AuditStatus
+	case entity.BulkDelete:
+		action = entity.AuditDelete
 	}
+
+	bulk.At = entity.WallClock(t.now())
+	var results []entity.BulkResult
+	err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var cascaded []int
+		var err error
+		if results, cascaded, err = t.taskRepo.Bulk(ctx, bulk); err != nil {
+			return err
+		}
+		if err := t.recordCascade(ctx, action, 0, bulk.UserID, cascaded); err != nil {
+			return err
+		}
+		for _, result := range results {
+			if result.Status != entity.BulkOK {
+				continue
+			}
+			if err := t.record(ctx, action, result.ID, result.Before.UserID, result.Before, result.Task); err != nil {
+				return err
+			}
+
+			if bulk.Action == entity.BulkComplete && !result.Before.Done && result.Task.RRule != "" {
+				if err := t.spawnNext(ctx, result.Task); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		if results[i].Status == entity.BulkOK {
+			t.markOverdue(results[i].Task)
+		}
+	}
+
+	return entity.NewBulkReport(bulk.Action, results), nil
+}
+
+// bulkQueryIDs возвращает задачи пользователя по фильтру групповой операции без пагинации.
+// Пустой фильтр отклоняется, чтобы операция не применилась ко всем задачам пользователя
+func (t *taskUsecase) bulkQueryIDs(ctx context.Context, query *entity.TaskQuery) ([]int, error) {
+	if query == nil {
+		return nil, apperror.ErrDataNotValid
+	}
+	query.Filter.Text = strings.TrimSpace(query.Filter.Text)
+	if query.Filter.IsEmpty() {
+		return nil, apperror.ErrDataNotValid
+	}
+	if err := t.prepareQuery(query); err != nil {
+		return nil, err
+	}
+	// Одна страница на maxBulkSize задач: total покажет, что по фильтру нашлось больше
+	query.Page, query.PageSize = 1, maxBulkSize
+	query.CursorMode, query.After, query.Before = false, nil, nil
+
+	tasks, total, _, err := t.taskRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if total > maxBulkSize {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids, nil
+}
+
+// uniqueIDs убирает повторы, сохраняя порядок
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// GetTask возвращает задачи пользователя по query одним запросом к репозиторию,
+// все заданные фильтры сочетаются между собой
+func (t *taskUsecase) GetTask(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error) {
+	query.Filter.Text = strings.TrimSpace(query.Filter.Text)
+	if err := t.prepareQuery(query); err != nil {
+		return nil, err
+	}
+
+	if query.CursorMode {
+		return t.getTaskByCursor(ctx, query)
+	}
+
+	tasks, total, _, err := t.taskRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTaskPage(t.markOverdueList(tasks), total, query.Page, query.PageSize), nil
 }
@@ -2,17 +2,39 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go-todolist-sber/internal/apperror"
+	auditMock "go-todolist-sber/internal/audit/mock"
 	"go-todolist-sber/internal/entity"
+	statusMock "go-todolist-sber/internal/status/mock"
+	statusUsecase "go-todolist-sber/internal/status/usecase"
 	"go-todolist-sber/internal/task/mock"
+	"strings"
 	"testing"
 	"time"
 )
 
+// inlineTx выполняет fn без транзакции и запоминает, чем она закончилась
+type inlineTx struct {
+	err error
+}
+
+func (i *inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	i.err = fn(ctx)
+	return i.err
+}
+
+// newAuditRepo возвращает журнал, принимающий любые записи
+func newAuditRepo(ctrl *gomock.Controller) *auditMock.MockAuditRepository {
+	auditRepo := auditMock.NewMockAuditRepository(ctrl)
+	auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return auditRepo
+}
+
 func TestTaskUsecase_CreateTask(t *testing.T) {
 	t.Parallel()
 
@@ -21,7 +43,7 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 
 	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
 
-	taskUsecase := NewTaskUsecase(mockTaskRepo)
+	taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
 
 	userID := uuid.New().String()
 
@@ -45,9 +67,58 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 	require.NotNil(t, createdTask)
 }
 
+func TestTaskUsecase_CreateTask_Priority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		priority     entity.Priority
+		wantPriority entity.Priority
+		wantErr      error
+	}{
+		{
+			name:         "default priority",
+			priority:     "",
+			wantPriority: entity.PriorityNone,
+			wantErr:      nil,
+		},
+		{
+			name:         "ok",
+			priority:     entity.PriorityUrgent,
+			wantPriority: entity.PriorityUrgent,
+			wantErr:      nil,
+		},
+		{
+			name:     "priority not valid",
+			priority: "critical",
+			wantErr:  apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			task := &entity.Task{Header: "Header", Priority: tt.priority}
+			if tt.wantErr == nil {
+				mockTaskRepo.EXPECT().Create(context.Background(), gomock.Eq(task)).Return(task, nil)
+			}
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+			createdTask, err := taskUsecase.CreateTask(context.Background(), task)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantPriority, createdTask.Priority)
+			}
+		})
+	}
+}
+
 func TestTaskUsecase_DeleteTask(t *testing.T) {
 	t.Parallel()
 
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
 	type mockBehavior func(r *mock.MockTaskRepository, id int)
 	type args struct {
 		id int
@@ -64,7 +135,8 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 			name: "ok",
 			args: args{id: 6},
 			mockBehavior: func(m *mock.MockTaskRepository, id int) {
-				m.EXPECT().DeleteByID(context.Background(), gomock.Eq(id)).Return(nil)
+				m.EXPECT().GetByID(context.Background(), gomock.Eq(id)).Return(&entity.Task{ID: id, UserID: "uuid"}, nil)
+				m.EXPECT().DeleteByID(context.Background(), gomock.Eq(id), now).Return([]int{id}, nil)
 			},
 			want:    "",
 			wantErr: nil,
@@ -73,7 +145,7 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 			name: "Not Found",
 			args: args{id: 15},
 			mockBehavior: func(m *mock.MockTaskRepository, id int) {
-				m.EXPECT().DeleteByID(context.Background(), gomock.Eq(id)).Return(apperror.ErrNoRows)
+				m.EXPECT().GetByID(context.Background(), gomock.Eq(id)).Return(nil, apperror.ErrNoRows)
 			},
 			want:    "",
 			wantErr: apperror.ErrNoRows,
@@ -85,7 +157,7 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
 			tt.mockBehavior(mockTaskRepo, tt.id)
-			taskUsecase := NewTaskUsecase(mockTaskRepo)
+			taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: newAuditRepo(ctrl), tx: &inlineTx{}, now: func() time.Time { return now }}
 			err := taskUsecase.DeleteTask(context.Background(), tt.args.id)
 			assert.Equal(t, tt.wantErr, err)
 			assert.Equal(t, tt.want, "")
@@ -96,6 +168,8 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 func TestTaskUsecase_UpdateTask(t *testing.T) {
 	t.Parallel()
 
+	createdAt := time.Now()
+
 	type mockBehavior func(r *mock.MockTaskRepository, task *entity.Task)
 	type args struct {
 		task *entity.Task
@@ -116,13 +190,14 @@ func TestTaskUsecase_UpdateTask(t *testing.T) {
 					UserID:      "uuid",
 					Header:      "Update Header",
 					Description: "Description",
-					CreatedAt:   time.Now(),
+					CreatedAt:   createdAt,
 				},
 			},
 			mockBehavior: func(m *mock.MockTaskRepository, task *entity.Task) {
+				m.EXPECT().GetByID(context.Background(), task.ID).Return(&entity.Task{ID: 1, UserID: "uuid", Header: "Header"}, nil)
 				m.EXPECT().Update(context.Background(), gomock.Eq(task)).Return(task, nil)
 			},
-			want:    &entity.Task{ID: 1, UserID: "uuid", Header: "Update Header", Description: "Description", CreatedAt: time.Now()},
+			want:    &entity.Task{ID: 1, UserID: "uuid", Header: "Update Header", Description: "Description", CreatedAt: createdAt},
 			wantErr: nil,
 		},
 		{
@@ -137,7 +212,7 @@ func TestTaskUsecase_UpdateTask(t *testing.T) {
 				},
 			},
 			mockBehavior: func(m *mock.MockTaskRepository, task *entity.Task) {
-				m.EXPECT().Update(context.Background(), gomock.Eq(task)).Return(nil, apperror.ErrNoRows)
+				m.EXPECT().GetByID(context.Background(), task.ID).Return(nil, apperror.ErrNoRows)
 			},
 			want:    nil,
 			wantErr: apperror.ErrNoRows,
@@ -149,7 +224,7 @@ func TestTaskUsecase_UpdateTask(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
 			tt.mockBehavior(mockTaskRepo, tt.task)
-			taskUsecase := NewTaskUsecase(mockTaskRepo)
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
 			updatedTask, err := taskUsecase.UpdateTask(context.Background(), tt.task)
 			assert.Equal(t, tt.wantErr, err)
 			assert.Equal(t, tt.want, updatedTask)
@@ -157,6 +232,104 @@ func TestTaskUsecase_UpdateTask(t *testing.T) {
 	}
 }
 
+func TestTaskUsecase_GetTask_Overdue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 12, 20, 15, 0, 0, 0, time.UTC)
+	date := func(hour int) *time.Time {
+		d := time.Date(2023, 12, 20, hour, 0, 0, 0, time.UTC)
+		return &d
+	}
+	yesterday := now.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name string
+		task entity.Task
+		want bool
+	}{
+		{name: "without due date", task: entity.Task{}, want: false},
+		{name: "time passed", task: entity.Task{DueDate: date(12), DueHasTime: true}, want: true},
+		{name: "time not passed", task: entity.Task{DueDate: date(18), DueHasTime: true}, want: false},
+		{name: "due today without time", task: entity.Task{DueDate: date(0)}, want: false},
+		{name: "due yesterday without time", task: entity.Task{DueDate: &yesterday}, want: true},
+		{name: "done", task: entity.Task{DueDate: date(12), DueHasTime: true, Done: true}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			mockTaskRepo.EXPECT().Find(context.Background(), &entity.TaskQuery{UserID: "uuid", PageSize: entity.DefaultPageSize}).Return([]entity.Task{tt.task}, 1, false, nil)
+
+			taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: newAuditRepo(ctrl), tx: &inlineTx{}, now: func() time.Time { return now }}
+			page, err := taskUsecase.GetTask(context.Background(), &entity.TaskQuery{UserID: "uuid"})
+			require.NoError(t, err)
+			require.Len(t, page.Items, 1)
+			assert.Equal(t, tt.want, page.Items[0].Overdue)
+		})
+	}
+}
+
+func TestTaskUsecase_SearchTasks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 12, 20, 15, 0, 0, 0, time.UTC)
+	due := time.Date(2023, 12, 19, 0, 0, 0, 0, time.UTC)
+	status := false
+
+	type mockBehavior func(r *mock.MockTaskRepository)
+
+	tests := []struct {
+		name         string
+		query        *entity.TaskQuery
+		mockBehavior mockBehavior
+		want         *entity.SearchPage
+		wantErr      error
+	}{
+		{
+			name:  "ok",
+			query: &entity.TaskQuery{UserID: "uuid", Page: 1, Filter: entity.TaskFilter{Text: "  report  ", Status: &status, Due: entity.DueOverdue}},
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				query := &entity.TaskQuery{UserID: "uuid", Page: 1, PageSize: entity.DefaultPageSize, Filter: entity.TaskFilter{Text: "report", Status: &status, Due: entity.DueOverdue, Now: now}}
+				r.EXPECT().Search(context.Background(), query).
+					Return([]entity.SearchResult{{Task: entity.Task{ID: 1, DueDate: &due}, Rank: 0.6, Headline: "Quarterly <mark>report</mark>"}}, 1, nil)
+			},
+			want: &entity.SearchPage{
+				Items:    []entity.SearchResult{{Task: entity.Task{ID: 1, DueDate: &due, Overdue: true}, Rank: 0.6, Headline: "Quarterly <mark>report</mark>"}},
+				PageInfo: entity.PageInfo{Total: 1, Page: 1, PageSize: entity.DefaultPageSize},
+			},
+			wantErr: nil,
+		},
+		{
+			name:         "empty query",
+			query:        &entity.TaskQuery{UserID: "uuid", Filter: entity.TaskFilter{Text: "   "}},
+			mockBehavior: func(r *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "too long query",
+			query:        &entity.TaskQuery{UserID: "uuid", Filter: entity.TaskFilter{Text: strings.Repeat("a", maxSearchQueryLength+1)}},
+			mockBehavior: func(r *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			tt.mockBehavior(mockTaskRepo)
+
+			taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: newAuditRepo(ctrl), tx: &inlineTx{}, now: func() time.Time { return now }}
+			results, err := taskUsecase.SearchTasks(context.Background(), tt.query)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, results)
+		})
+	}
+}
+
 func TestTaskUsecase_IsEqualUserID(t *testing.T) {
 	t.Parallel()
 
@@ -203,7 +376,7 @@ func TestTaskUsecase_IsEqualUserID(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
 			tt.mockBehavior(mockTaskRepo, tt.contextUserID, tt.taskID)
-			taskUsecase := NewTaskUsecase(mockTaskRepo)
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
 			equal, err := taskUsecase.IsEqualUserID(context.Background(), tt.contextUserID, tt.taskID)
 			assert.Equal(t, tt.wantErr, err)
 			assert.Equal(t, tt.want, equal)
@@ -219,96 +392,1060 @@ func TestTaskUsecase_UpdateTaskStatus(t *testing.T) {
 
 	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
 
-	taskUsecase := NewTaskUsecase(mockTaskRepo)
+	taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
 
 	taskID := 1
 	status := true
 
-	mockTaskRepo.EXPECT().UpdateDone(gomock.Any(), gomock.Eq(status), gomock.Eq(taskID)).Return(&entity.Task{}, nil)
+	mockTaskRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(taskID)).Return(&entity.Task{ID: taskID}, nil)
+	mockTaskRepo.EXPECT().UpdateDone(gomock.Any(), gomock.Eq(status), gomock.Eq(taskID), 0).Return(&entity.Task{}, nil)
 
-	updatedTask, err := taskUsecase.UpdateTaskStatus(context.Background(), status, taskID)
+	updatedTask, err := taskUsecase.UpdateTaskStatus(context.Background(), status, taskID, false, 0)
 	require.NoError(t, err)
 	require.NotNil(t, updatedTask)
 }
 
-func TestTaskUsecase_GetTask(t *testing.T) {
+func TestTaskUsecase_UpdateTaskStatus_Cascade(t *testing.T) {
 	t.Parallel()
 
-	type mockBehavior func(r *mock.MockTaskRepository, userID string, date time.Time, status bool, offset int)
-	type args struct {
-		userID string
-		option *entity.ParamOption
-		offset int
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+	mockAuditRepo := auditMock.NewMockAuditRepository(ctrl)
+
+	taskUsecase := NewTaskUsecase(mockTaskRepo, mockAuditRepo, nil, &inlineTx{})
+
+	taskID := 1
+	status := true
+
+	mockTaskRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(taskID)).Return(&entity.Task{ID: taskID, ChildCount: 2}, nil)
+	mockTaskRepo.EXPECT().UpdateDoneWithChildren(gomock.Any(), gomock.Eq(status), gomock.Eq(taskID), 0).
+		Return(&entity.Task{ID: taskID, Done: true, ChildCount: 2, ChildDone: 2, Progress: 100}, []int{taskID, 2, 3}, nil)
+	// по записи в журнале на задачу и каждую подзадачу
+	mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	updatedTask, err := taskUsecase.UpdateTaskStatus(context.Background(), status, taskID, true, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 100, updatedTask.Progress)
+}
+
+func TestTaskUsecase_MoveTask(t *testing.T) {
+	t.Parallel()
+
+	projectID := 3
+
+	type mockBehavior func(r *mock.MockTaskRepository, taskID int, userID string, projectID *int)
+	tests := []struct {
+		name         string
+		projectID    *int
+		mockBehavior mockBehavior
+		want         *entity.Task
+		wantErr      error
+	}{
+		{
+			name:      "ok",
+			projectID: &projectID,
+			mockBehavior: func(r *mock.MockTaskRepository, taskID int, userID string, projectID *int) {
+				r.EXPECT().UpdateProject(context.Background(), taskID, userID, projectID).
+					Return(&entity.Task{ID: taskID, ProjectID: projectID}, nil)
+			},
+			want:    &entity.Task{ID: 1, ProjectID: &projectID},
+			wantErr: nil,
+		},
+		{
+			name:      "archived or foreign project",
+			projectID: &projectID,
+			mockBehavior: func(r *mock.MockTaskRepository, taskID int, userID string, projectID *int) {
+				r.EXPECT().UpdateProject(context.Background(), taskID, userID, projectID).Return(nil, apperror.ErrDataNotValid)
+			},
+			want:    nil,
+			wantErr: apperror.ErrDataNotValid,
+		},
+		{
+			name:      "out of project",
+			projectID: nil,
+			mockBehavior: func(r *mock.MockTaskRepository, taskID int, userID string, projectID *int) {
+				r.EXPECT().UpdateProject(context.Background(), taskID, userID, gomock.Nil()).Return(&entity.Task{ID: taskID}, nil)
+			},
+			want:    &entity.Task{ID: 1},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			mockTaskRepo.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid"}, nil)
+			tt.mockBehavior(mockTaskRepo, 1, "uuid", tt.projectID)
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+			task, err := taskUsecase.MoveTask(context.Background(), 1, "uuid", tt.projectID)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, task)
+		})
 	}
+}
+
+func TestTaskUsecase_UpdateTaskStatus_Recurring(t *testing.T) {
+	t.Parallel()
+
+	seriesID := 7
+	start := time.Date(2023, 12, 1, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2023, 12, 1, 18, 0, 0, 0, time.UTC)
+
+	completed := &entity.Task{
+		ID:         9,
+		Done:       true,
+		UserID:     "uuid",
+		Header:     "Standup",
+		StartDate:  start,
+		Priority:   entity.PriorityHigh,
+		DueDate:    &due,
+		DueHasTime: true,
+		Tags:       []entity.Tag{{ID: 2}},
+		RRule:      "FREQ=WEEKLY;BYDAY=MO,FR",
+		SeriesID:   &seriesID,
+		Occurrence: 3,
+	}
+
+	nextDue := time.Date(2023, 12, 4, 18, 0, 0, 0, time.UTC)
+	next := &entity.Task{
+		UserID:     "uuid",
+		Header:     "Standup",
+		StartDate:  time.Date(2023, 12, 4, 9, 0, 0, 0, time.UTC),
+		Priority:   entity.PriorityHigh,
+		DueDate:    &nextDue,
+		DueHasTime: true,
+		TagIDs:     []int{2},
+		RRule:      "FREQ=WEEKLY;BYDAY=MO,FR",
+		SeriesID:   &seriesID,
+		Occurrence: 4,
+	}
+
+	tests := []struct {
+		name      string
+		created   *entity.Task
+		createErr error
+		want      *entity.Task
+		wantErr   error
+	}{
+		{name: "next occurrence created", created: &entity.Task{ID: 10, UserID: "uuid"}, createErr: nil, want: completed, wantErr: nil},
+		{name: "next occurrence already exists", created: nil, createErr: apperror.ErrUniqueViolation, want: completed, wantErr: nil},
+		// завершение и следующее повторение создаются в одной транзакции, ошибка отменяет и завершение
+		{name: "next occurrence fails", created: nil, createErr: apperror.ErrForeignKeyViolation, want: nil, wantErr: apperror.ErrForeignKeyViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			mockTaskRepo.EXPECT().GetByID(context.Background(), completed.ID).Return(&entity.Task{ID: completed.ID, UserID: "uuid"}, nil)
+			mockTaskRepo.EXPECT().UpdateDone(context.Background(), true, completed.ID, 0).Return(completed, nil)
+			mockTaskRepo.EXPECT().Create(context.Background(), gomock.Eq(next)).Return(tt.created, tt.createErr)
+
+			tx := &inlineTx{}
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, tx)
+			updatedTask, err := taskUsecase.UpdateTaskStatus(context.Background(), true, completed.ID, false, 0)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantErr, tx.err)
+			assert.Equal(t, tt.want, updatedTask)
+		})
+	}
+}
+
+func TestTaskUsecase_CreateTask_RRule(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+	taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+
+	_, err := taskUsecase.CreateTask(context.Background(), &entity.Task{Header: "Header", RRule: "FREQ=SECONDLY"})
+	assert.Equal(t, apperror.ErrDataNotValid, err)
+
+	task := &entity.Task{Header: "Header", RRule: "rrule:freq=daily;interval=2"}
+	mockTaskRepo.EXPECT().Create(context.Background(), gomock.Any()).Return(task, nil)
+
+	createdTask, err := taskUsecase.CreateTask(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=DAILY;INTERVAL=2", createdTask.RRule)
+}
+
+func TestTaskUsecase_GetTask(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2023, 12, 31, 18, 0, 0, 0, time.UTC)
+	high := entity.PriorityHigh
+
+	type mockBehavior func(r *mock.MockTaskRepository)
 	tests := []struct {
 		name         string
-		args         args
+		query        *entity.TaskQuery
 		mockBehavior mockBehavior
-		want         []entity.Task
+		want         *entity.TaskPage
 		wantErr      error
 	}{
 		{
 			name: "ok with date and status",
-			args: args{
-				userID: "uuid",
-				option: &entity.ParamOption{
-					Page:     0,
-					Status:   &[]bool{true}[0],
-					DateTime: time.Now(),
-				},
+			query: &entity.TaskQuery{
+				UserID: "uuid",
+				Filter: entity.TaskFilter{Status: &[]bool{true}[0], StartRange: entity.TimeRange{From: &date, To: &date}},
 			},
-			mockBehavior: func(m *mock.MockTaskRepository, userID string, date time.Time, status bool, offset int) {
-				m.EXPECT().GetByDateAndStatus(context.Background(), gomock.Eq(userID), gomock.Eq(date), gomock.Eq(status)).Return([]entity.Task{}, nil)
+			mockBehavior: func(m *mock.MockTaskRepository) {
+				m.EXPECT().Find(context.Background(), &entity.TaskQuery{
+					UserID:   "uuid",
+					Filter:   entity.TaskFilter{Status: &[]bool{true}[0], StartRange: entity.TimeRange{From: &date, To: &date}},
+					PageSize: entity.DefaultPageSize,
+				}).Return([]entity.Task{{ID: 1}}, 1, false, nil)
 			},
-			want:    []entity.Task{},
+			want:    &entity.TaskPage{Items: []entity.Task{{ID: 1}}, PageInfo: entity.PageInfo{Total: 1}},
 			wantErr: nil,
 		},
 		{
-			name: "ok with pagination and filter",
-			args: args{
-				userID: "uuid",
-				option: &entity.ParamOption{
-					Page:     1,
-					Status:   &[]bool{true}[0],
-					DateTime: time.Now(),
-				},
-				offset: 0,
+			name: "ok with pagination and empty result",
+			query: &entity.TaskQuery{
+				UserID: "uuid",
+				Page:   1,
+				Filter: entity.TaskFilter{Status: &[]bool{true}[0]},
 			},
-			mockBehavior: func(m *mock.MockTaskRepository, userID string, date time.Time, status bool, offset int) {
-				m.EXPECT().GetByDateAndStatusWithOffset(context.Background(), userID, date, status, offset).Return([]entity.Task{}, nil)
+			mockBehavior: func(m *mock.MockTaskRepository) {
+				m.EXPECT().Find(context.Background(), gomock.Any()).Return([]entity.Task{}, 0, false, nil)
 			},
-			want:    []entity.Task{},
+			want:    &entity.TaskPage{Items: []entity.Task{}, PageInfo: entity.PageInfo{Page: 1, PageSize: entity.DefaultPageSize}},
 			wantErr: nil,
 		},
 		{
-			name: "ok with pagination",
-			args: args{
-				userID: "uuid",
-				option: &entity.ParamOption{
-					Page:   3,
-					Status: &[]bool{true}[0],
-				},
-				offset: 6,
+			name: "ok with combined filters",
+			query: &entity.TaskQuery{
+				UserID: "uuid",
+				Page:   3,
+				Filter: entity.TaskFilter{Status: &[]bool{true}[0], Priority: &high, Text: " report ", Tags: []int{1, 2}, TagMode: entity.TagModeAll},
+			},
+			mockBehavior: func(m *mock.MockTaskRepository) {
+				m.EXPECT().Find(context.Background(), &entity.TaskQuery{
+					UserID:   "uuid",
+					Page:     3,
+					PageSize: entity.DefaultPageSize,
+					Filter:   entity.TaskFilter{Status: &[]bool{true}[0], Priority: &high, Text: "report", Tags: []int{1, 2}, TagMode: entity.TagModeAll},
+				}).Return([]entity.Task{{ID: 3}}, 7, false, nil)
+			},
+			want:    &entity.TaskPage{Items: []entity.Task{{ID: 3}}, PageInfo: entity.PageInfo{Total: 7, Page: 3, PageSize: entity.DefaultPageSize, PrevPage: &[]int{2}[0]}},
+			wantErr: nil,
+		},
+		{
+			name: "ok with page size and sort order",
+			query: &entity.TaskQuery{
+				UserID:   "uuid",
+				Page:     2,
+				PageSize: 10,
+				Sort:     entity.TaskSort{Field: entity.SortByDueDate, Order: entity.SortAsc},
+				Filter:   entity.TaskFilter{Status: &[]bool{false}[0]},
+			},
+			mockBehavior: func(m *mock.MockTaskRepository) {
+				m.EXPECT().Find(context.Background(), &entity.TaskQuery{
+					UserID:   "uuid",
+					Page:     2,
+					PageSize: 10,
+					Sort:     entity.TaskSort{Field: entity.SortByDueDate, Order: entity.SortAsc},
+					Filter:   entity.TaskFilter{Status: &[]bool{false}[0]},
+				}).Return([]entity.Task{{ID: 11}}, 25, false, nil)
+			},
+			want:    &entity.TaskPage{Items: []entity.Task{{ID: 11}}, PageInfo: entity.PageInfo{Total: 25, Page: 2, PageSize: 10, NextPage: &[]int{3}[0], PrevPage: &[]int{1}[0]}},
+			wantErr: nil,
+		},
+		{
+			name: "too long text",
+			query: &entity.TaskQuery{
+				UserID: "uuid",
+				Filter: entity.TaskFilter{Text: strings.Repeat("a", maxSearchQueryLength+1)},
+			},
+			mockBehavior: func(m *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			tt.mockBehavior(mockTaskRepo)
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+			page, err := taskUsecase.GetTask(context.Background(), tt.query)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestTaskUsecase_GetTask_Cursor(t *testing.T) {
+	t.Parallel()
+
+	sort := entity.TaskSort{Field: entity.SortByPriority}
+	tasks := []entity.Task{{ID: 9, Priority: entity.PriorityUrgent}, {ID: 4, Priority: entity.PriorityHigh}}
+
+	tests := []struct {
+		name       string
+		query      *entity.TaskQuery
+		more       bool
+		wantPrev   int
+		wantNext   int
+		wantErr    error
+		noRepoCall bool
+	}{
+		{
+			name:     "first page",
+			query:    &entity.TaskQuery{UserID: "uuid", CursorMode: true, PageSize: 2, Sort: sort},
+			more:     true,
+			wantPrev: 0,
+			wantNext: 4,
+		},
+		{
+			name:     "last page after cursor",
+			query:    &entity.TaskQuery{UserID: "uuid", CursorMode: true, PageSize: 2, Sort: sort, After: entity.NewCursor(&entity.Task{ID: 12, Priority: entity.PriorityUrgent}, sort)},
+			more:     false,
+			wantPrev: 9,
+			wantNext: 0,
+		},
+		{
+			name:     "page before cursor",
+			query:    &entity.TaskQuery{UserID: "uuid", CursorMode: true, PageSize: 2, Sort: sort, Before: entity.NewCursor(&entity.Task{ID: 2, Priority: entity.PriorityHigh}, sort)},
+			more:     true,
+			wantPrev: 9,
+			wantNext: 4,
+		},
+		{
+			name:       "cursor from another sort",
+			query:      &entity.TaskQuery{UserID: "uuid", CursorMode: true, PageSize: 2, Sort: sort, After: entity.NewCursor(&entity.Task{ID: 12}, entity.TaskSort{})},
+			wantErr:    apperror.ErrDataNotValid,
+			noRepoCall: true,
+		},
+	}
+
+	cursorID := func(t *testing.T, value string) int {
+		if value == "" {
+			return 0
+		}
+		cursor, err := entity.DecodeCursor(value)
+		require.NoError(t, err)
+		return cursor.ID
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			if !tt.noRepoCall {
+				mockTaskRepo.EXPECT().Find(context.Background(), tt.query).Return(tasks, 5, tt.more, nil)
+			}
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+			page, err := taskUsecase.GetTask(context.Background(), tt.query)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+
+			assert.Equal(t, tasks, page.Items)
+			assert.Equal(t, 5, page.Total)
+			assert.Equal(t, 2, page.PageSize)
+			assert.Nil(t, page.NextPage)
+			assert.Equal(t, tt.wantPrev, cursorID(t, page.PrevCursor))
+			assert.Equal(t, tt.wantNext, cursorID(t, page.NextCursor))
+		})
+	}
+}
+
+func TestTaskUsecase_GetTask_DateRange(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 12, 31, 23, 59, 59, 999999000, time.UTC)
+	filter := entity.TaskFilter{StartRange: entity.TimeRange{From: &from, To: &to}, DueRange: entity.TimeRange{To: &to}}
+
+	ctrl := gomock.NewController(t)
+	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+	mockTaskRepo.EXPECT().Find(context.Background(), &entity.TaskQuery{UserID: "uuid", Page: 1, PageSize: entity.DefaultPageSize, Filter: filter}).Return([]entity.Task{{ID: 1}}, 1, false, nil)
+
+	taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+	page, err := taskUsecase.GetTask(context.Background(), &entity.TaskQuery{UserID: "uuid", Page: 1, Filter: filter})
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Task{{ID: 1}}, page.Items)
+}
+
+func TestTaskUsecase_MoveTaskToStatus(t *testing.T) {
+	t.Parallel()
+
+	todoID, reviewID := 1, 3
+	statuses := []entity.Status{
+		{ID: 1, Name: "todo", Transitions: []int{2}},
+		{ID: 2, Name: "in_progress"},
+		{ID: 3, Name: "review", Transitions: []int{2, 4}},
+		{ID: 4, Name: "done", Done: true},
+	}
+
+	type mockBehavior func(r *mock.MockTaskRepository)
+
+	tests := []struct {
+		name         string
+		task         *entity.Task
+		status       string
+		mockBehavior mockBehavior
+		want         *entity.Task
+		wantErr      error
+	}{
+		{
+			name:   "allowed transition",
+			task:   &entity.Task{ID: 5, StatusID: &todoID},
+			status: " In_Progress ",
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().UpdateStatus(context.Background(), 5, 2, false, 0).Return(&entity.Task{ID: 5, StatusID: &[]int{2}[0]}, nil)
+			},
+			want:    &entity.Task{ID: 5, StatusID: &[]int{2}[0]},
+			wantErr: nil,
+		},
+		{
+			name:   "done column completes task",
+			task:   &entity.Task{ID: 5, StatusID: &reviewID},
+			status: "done",
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().UpdateStatus(context.Background(), 5, 4, true, 0).Return(&entity.Task{ID: 5, Done: true, StatusID: &[]int{4}[0]}, nil)
+			},
+			want:    &entity.Task{ID: 5, Done: true, StatusID: &[]int{4}[0]},
+			wantErr: nil,
+		},
+		{
+			name:         "transition not allowed",
+			task:         &entity.Task{ID: 5, StatusID: &todoID},
+			status:       "done",
+			mockBehavior: func(r *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrTransitionNotAllowed,
+		},
+		{
+			name:         "task without status starts in first column",
+			task:         &entity.Task{ID: 5},
+			status:       "review",
+			mockBehavior: func(r *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrTransitionNotAllowed,
+		},
+		{
+			name:         "unknown column",
+			task:         &entity.Task{ID: 5},
+			status:       "archive",
+			mockBehavior: func(r *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			mockStatusRepo := statusMock.NewMockStatusRepository(ctrl)
+			mockTaskRepo.EXPECT().GetByID(context.Background(), 5).Return(tt.task, nil)
+			mockStatusRepo.EXPECT().GetByScope(context.Background(), "uuid", nil).Return(statuses, nil)
+			tt.mockBehavior(mockTaskRepo)
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), statusUsecase.NewStatusUsecase(mockStatusRepo), &inlineTx{})
+			task, err := taskUsecase.MoveTaskToStatus(context.Background(), 5, "uuid", tt.status, 0)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, task)
+		})
+	}
+}
+
+func TestTaskUsecase_UpdateColumn(t *testing.T) {
+	t.Parallel()
+
+	stored := &entity.Status{ID: 3, UserID: "uuid", Name: "review"}
+	yes, no := true, false
+
+	type mockBehavior func(r *mock.MockTaskRepository, s *statusMock.MockStatusRepository, a *auditMock.MockAuditRepository)
+
+	tests := []struct {
+		name         string
+		done         *bool
+		mockBehavior mockBehavior
+		want         *entity.Status
+	}{
+		{
+			name: "done omitted keeps the flag and tasks",
+			done: nil,
+			mockBehavior: func(r *mock.MockTaskRepository, s *statusMock.MockStatusRepository, a *auditMock.MockAuditRepository) {
+				s.EXPECT().Update(context.Background(), &entity.Status{ID: 3, UserID: "uuid", Name: "checking"}).
+					Return(&entity.Status{ID: 3, UserID: "uuid", Name: "checking"}, nil)
+			},
+			want: &entity.Status{ID: 3, UserID: "uuid", Name: "checking"},
+		},
+		{
+			name: "same flag keeps tasks",
+			done: &no,
+			mockBehavior: func(r *mock.MockTaskRepository, s *statusMock.MockStatusRepository, a *auditMock.MockAuditRepository) {
+				s.EXPECT().Update(context.Background(), gomock.Any()).Return(&entity.Status{ID: 3, UserID: "uuid", Name: "checking"}, nil)
+			},
+			want: &entity.Status{ID: 3, UserID: "uuid", Name: "checking"},
+		},
+		{
+			name: "done column completes open tasks one by one",
+			done: &yes,
+			mockBehavior: func(r *mock.MockTaskRepository, s *statusMock.MockStatusRepository, a *auditMock.MockAuditRepository) {
+				start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+				s.EXPECT().Update(context.Background(), &entity.Status{ID: 3, UserID: "uuid", Name: "checking", Done: true}).
+					Return(&entity.Status{ID: 3, UserID: "uuid", Name: "checking", Done: true}, nil)
+				r.EXPECT().GetByStatusID(context.Background(), 3).Return([]entity.Task{
+					{ID: 5, UserID: "uuid", Version: 2},
+					{ID: 6, UserID: "uuid", Done: true, Version: 4},
+					{ID: 7, UserID: "uuid", Version: 1, RRule: "FREQ=DAILY", StartDate: start},
+				}, nil)
+				r.EXPECT().UpdateStatus(context.Background(), 5, 3, true, 2).Return(&entity.Task{ID: 5, UserID: "uuid", Done: true, Version: 3}, nil)
+				r.EXPECT().UpdateStatus(context.Background(), 7, 3, true, 1).
+					Return(&entity.Task{ID: 7, UserID: "uuid", Done: true, Version: 2, RRule: "FREQ=DAILY", StartDate: start}, nil)
+				r.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, task *entity.Task) (*entity.Task, error) {
+					assert.Equal(t, start.AddDate(0, 0, 1), task.StartDate)
+					task.ID = 8
+					return task, nil
+				})
+				a.EXPECT().Create(context.Background(), gomock.Any()).Return(nil).Times(3)
+			},
+			want: &entity.Status{ID: 3, UserID: "uuid", Name: "checking", Done: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			mockStatusRepo := statusMock.NewMockStatusRepository(ctrl)
+			mockAuditRepo := auditMock.NewMockAuditRepository(ctrl)
+			current := *stored
+			mockStatusRepo.EXPECT().GetByID(context.Background(), 3).Return(&current, nil)
+			tt.mockBehavior(mockTaskRepo, mockStatusRepo, mockAuditRepo)
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, mockAuditRepo, statusUsecase.NewStatusUsecase(mockStatusRepo), &inlineTx{})
+			column, err := taskUsecase.UpdateColumn(context.Background(), &entity.Status{ID: 3, UserID: "uuid", Name: " checking "}, tt.done)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, column)
+		})
+	}
+}
+
+func TestTaskUsecase_GetBoard(t *testing.T) {
+	t.Parallel()
+
+	statuses := []entity.Status{{ID: 1, Name: "todo"}, {ID: 2, Name: "in_progress"}, {ID: 3, Name: "done", Done: true}}
+	tasks := []entity.Task{
+		{ID: 9, StatusID: &[]int{2}[0]},
+		{ID: 8},
+		{ID: 7, Done: true},
+		{ID: 6, StatusID: &[]int{42}[0]},
+		{ID: 5, StatusID: &[]int{2}[0]},
+	}
+	sort := entity.TaskSort{Field: entity.SortByPriority}
+
+	ctrl := gomock.NewController(t)
+	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+	mockStatusRepo := statusMock.NewMockStatusRepository(ctrl)
+	mockStatusRepo.EXPECT().GetByScope(context.Background(), "uuid", nil).Return(statuses, nil)
+	mockTaskRepo.EXPECT().Find(context.Background(), &entity.TaskQuery{UserID: "uuid", Sort: sort, PageSize: entity.DefaultPageSize}).Return(tasks, 5, false, nil)
+
+	taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), statusUsecase.NewStatusUsecase(mockStatusRepo), &inlineTx{})
+	board, err := taskUsecase.GetBoard(context.Background(), &entity.TaskQuery{UserID: "uuid", Sort: sort})
+	require.NoError(t, err)
+	require.Len(t, board.Columns, 3)
+
+	ids := func(tasks []entity.Task) []int {
+		result := make([]int, 0, len(tasks))
+		for _, task := range tasks {
+			result = append(result, task.ID)
+		}
+		return result
+	}
+	assert.Equal(t, []int{8, 6}, ids(board.Columns[0].Tasks))
+	assert.Equal(t, []int{9, 5}, ids(board.Columns[1].Tasks))
+	assert.Equal(t, []int{7}, ids(board.Columns[2].Tasks))
+	assert.Equal(t, "done", board.Columns[2].Status.Name)
+}
+
+func TestTaskUsecase_ReorderTask(t *testing.T) {
+	t.Parallel()
+
+	after, before, self := 3, 4, 5
+
+	type mockBehavior func(r *mock.MockTaskRepository)
+
+	tests := []struct {
+		name         string
+		afterID      *int
+		beforeID     *int
+		mockBehavior mockBehavior
+		want         *entity.Task
+		wantErr      error
+	}{
+		{
+			name:     "between neighbours",
+			afterID:  &after,
+			beforeID: &before,
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 5).Return(&entity.Task{ID: 5, Position: "i"}, nil)
+				r.EXPECT().UpdatePosition(context.Background(), 5, "uuid", &after, &before).Return(&entity.Task{ID: 5, Position: "ai"}, nil)
 			},
-			mockBehavior: func(m *mock.MockTaskRepository, userID string, date time.Time, status bool, offset int) {
-				m.EXPECT().GetByStatusWithOffset(context.Background(), userID, status, offset).Return([]entity.Task{}, nil)
+			want:    &entity.Task{ID: 5, Position: "ai"},
+			wantErr: nil,
+		},
+		{
+			name:    "after only",
+			afterID: &after,
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 5).Return(&entity.Task{ID: 5, Position: "i"}, nil)
+				r.EXPECT().UpdatePosition(context.Background(), 5, "uuid", &after, nil).Return(&entity.Task{ID: 5, Position: "r"}, nil)
+			},
+			want:    &entity.Task{ID: 5, Position: "r"},
+			wantErr: nil,
+		},
+		{
+			name:         "without neighbours",
+			mockBehavior: func(r *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "neighbour is the task itself",
+			beforeID:     &self,
+			mockBehavior: func(r *mock.MockTaskRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			tt.mockBehavior(mockTaskRepo)
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+			task, err := taskUsecase.ReorderTask(context.Background(), 5, "uuid", tt.afterID, tt.beforeID)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, task)
+		})
+	}
+}
+
+func TestTaskUsecase_DeleteTaskPermanently(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+	mockAuditRepo := auditMock.NewMockAuditRepository(ctrl)
+
+	mockTaskRepo.EXPECT().DeletePermanently(context.Background(), 5, "uuid").Return([]int{5, 6, 7}, nil)
+	var purged []int
+	mockAuditRepo.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, entry *entity.AuditEntry) error {
+		assert.Equal(t, entity.AuditPurge, entry.Action)
+		assert.Equal(t, "uuid", entry.UserID)
+		purged = append(purged, entry.TaskID)
+		return nil
+	}).Times(3)
+
+	taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: mockAuditRepo, tx: &inlineTx{}, now: func() time.Time { return now }}
+	require.NoError(t, taskUsecase.DeleteTaskPermanently(context.Background(), 5, "uuid"))
+	assert.Equal(t, []int{5, 6, 7}, purged)
+}
+
+func TestTaskUsecase_RestoreTask(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(r *mock.MockTaskRepository)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         *entity.Task
+		wantErr      error
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().Restore(context.Background(), 5, "uuid").Return(&entity.Task{ID: 5, DueDate: &due}, []int{5}, nil)
 			},
-			want:    []entity.Task{},
+			want:    &entity.Task{ID: 5, DueDate: &due, Overdue: true},
 			wantErr: nil,
 		},
+		{
+			name: "not in trash",
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().Restore(context.Background(), 5, "uuid").Return(nil, nil, apperror.ErrNoRows)
+			},
+			want:    nil,
+			wantErr: apperror.ErrNoRows,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
-			tt.mockBehavior(mockTaskRepo, tt.args.userID, tt.args.option.DateTime, *tt.args.option.Status, tt.args.offset)
+			tt.mockBehavior(mockTaskRepo)
+
+			taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: newAuditRepo(ctrl), tx: &inlineTx{}, now: func() time.Time { return now }}
+			task, err := taskUsecase.RestoreTask(context.Background(), 5, "uuid")
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, task)
+		})
+	}
+}
+
+func TestTaskUsecase_UpdateTaskStatus_Audit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	before := &entity.Task{ID: 5, UserID: "owner", Header: "Header"}
+	after := &entity.Task{ID: 5, UserID: "owner", Header: "Header", Done: true}
+
+	ctrl := gomock.NewController(t)
+	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+	mockAuditRepo := auditMock.NewMockAuditRepository(ctrl)
+
+	ctx := entity.WithActor(context.Background(), entity.Actor{UserID: "owner", RequestID: "req-1"})
+
+	mockTaskRepo.EXPECT().GetByID(ctx, 5).Return(before, nil)
+	mockTaskRepo.EXPECT().UpdateDone(ctx, true, 5, 0).Return(after, nil)
+	mockAuditRepo.EXPECT().Create(ctx, &entity.AuditEntry{
+		TaskID:    5,
+		Action:    entity.AuditStatus,
+		UserID:    "owner",
+		ActorID:   "owner",
+		Before:    before,
+		After:     after,
+		RequestID: "req-1",
+		CreatedAt: now,
+	}).Return(nil)
 
-			taskUsecase := NewTaskUsecase(mockTaskRepo)
-			tasks, err := taskUsecase.GetTask(context.Background(), tt.args.userID, tt.args.option)
+	taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: mockAuditRepo, tx: &inlineTx{}, now: func() time.Time { return now }}
+	_, err := taskUsecase.UpdateTaskStatus(ctx, true, 5, false, 0)
+	require.NoError(t, err)
+}
+
+func TestTaskUsecase_UpdateTaskStatus_AuditFailure(t *testing.T) {
+	t.Parallel()
+
+	before := &entity.Task{ID: 5, UserID: "owner", Header: "Header"}
+	after := &entity.Task{ID: 5, UserID: "owner", Header: "Header", Done: true}
+	auditErr := errors.New("connection refused")
+
+	ctrl := gomock.NewController(t)
+	mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+	mockAuditRepo := auditMock.NewMockAuditRepository(ctrl)
+
+	mockTaskRepo.EXPECT().GetByID(context.Background(), 5).Return(before, nil)
+	mockTaskRepo.EXPECT().UpdateDone(context.Background(), true, 5, 0).Return(after, nil)
+	mockAuditRepo.EXPECT().Create(context.Background(), gomock.Any()).Return(auditErr)
+
+	tx := &inlineTx{}
+	taskUsecase := NewTaskUsecase(mockTaskRepo, mockAuditRepo, nil, tx)
+	task, err := taskUsecase.UpdateTaskStatus(context.Background(), true, 5, false, 0)
+	assert.Nil(t, task)
+	assert.Equal(t, auditErr, err)
+	// ошибка журнала откатывает транзакцию вместе с изменением задачи
+	assert.Equal(t, auditErr, tx.err)
+}
+
+func TestTaskUsecase_UpdateTask_Version(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *mock.MockTaskRepository)
+
+	tests := []struct {
+		name         string
+		version      int
+		mockBehavior mockBehavior
+		want         *entity.Task
+		wantErr      error
+	}{
+		{
+			name:    "matching version",
+			version: 3,
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Version: 3}, nil)
+				r.EXPECT().Update(context.Background(), gomock.Any()).Return(&entity.Task{ID: 1, UserID: "uuid", Header: "New", Version: 4}, nil)
+			},
+			want:    &entity.Task{ID: 1, UserID: "uuid", Header: "New", Version: 4},
+			wantErr: nil,
+		},
+		{
+			name:    "stale version",
+			version: 2,
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Version: 3}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrVersionMismatch,
+		},
+		{
+			name:    "changed between read and update",
+			version: 3,
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Version: 3}, nil)
+				r.EXPECT().Update(context.Background(), gomock.Any()).Return(nil, apperror.ErrNoRows)
+			},
+			want:    nil,
+			wantErr: apperror.ErrVersionMismatch,
+		},
+		{
+			name:    "without version",
+			version: 0,
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Version: 3}, nil)
+				r.EXPECT().Update(context.Background(), gomock.Any()).Return(&entity.Task{ID: 1, UserID: "uuid", Header: "New", Version: 4}, nil)
+			},
+			want:    &entity.Task{ID: 1, UserID: "uuid", Header: "New", Version: 4},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			tt.mockBehavior(mockTaskRepo)
+
+			taskUsecase := NewTaskUsecase(mockTaskRepo, newAuditRepo(ctrl), nil, &inlineTx{})
+			task, err := taskUsecase.UpdateTask(context.Background(), &entity.Task{ID: 1, UserID: "uuid", Header: "New", Version: tt.version})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, task)
+		})
+	}
+}
+
+func TestTaskUsecase_PatchTask(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	parentID := 1
+
+	type mockBehavior func(r *mock.MockTaskRepository)
+
+	tests := []struct {
+		name         string
+		task         *entity.Task
+		mockBehavior mockBehavior
+		want         *entity.Task
+		wantErr      error
+	}{
+		{
+			name: "clears fields",
+			task: &entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: entity.PriorityNone, TagIDs: []int{}, Version: 3},
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Header: "Header", Description: "Description", Version: 3}, nil)
+				r.EXPECT().Replace(context.Background(), &entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: entity.PriorityNone, TagIDs: []int{}, Version: 3}).
+					Return(&entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: entity.PriorityNone, Version: 4}, nil)
+			},
+			want:    &entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: entity.PriorityNone, Version: 4},
+			wantErr: nil,
+		},
+		{
+			name: "invalid priority",
+			task: &entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: "critical"},
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Version: 3}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrDataNotValid,
+		},
+		{
+			name: "parent is the task itself",
+			task: &entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: entity.PriorityNone, ParentID: &parentID},
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Version: 3}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrDataNotValid,
+		},
+		{
+			name: "changed between read and update",
+			task: &entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: entity.PriorityNone, Version: 3},
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", Version: 3}, nil)
+				r.EXPECT().Replace(context.Background(), gomock.Any()).Return(nil, apperror.ErrNoRows)
+			},
+			want:    nil,
+			wantErr: apperror.ErrVersionMismatch,
+		},
+		{
+			name: "completing recurring task spawns next occurrence",
+			task: &entity.Task{ID: 1, UserID: "uuid", Header: "Header", Priority: entity.PriorityNone, StartDate: start, Done: true, RRule: "FREQ=DAILY", Version: 3},
+			mockBehavior: func(r *mock.MockTaskRepository) {
+				r.EXPECT().GetByID(context.Background(), 1).Return(&entity.Task{ID: 1, UserID: "uuid", StartDate: start, RRule: "FREQ=DAILY", Version: 3}, nil)
+				r.EXPECT().Replace(context.Background(), gomock.Any()).
+					Return(&entity.Task{ID: 1, UserID: "uuid", Header: "Header", StartDate: start, Done: true, RRule: "FREQ=DAILY", Version: 4}, nil)
+				r.EXPECT().Create(context.Background(), gomock.Any()).Return(&entity.Task{ID: 2, UserID: "uuid"}, nil)
+			},
+			want:    &entity.Task{ID: 1, UserID: "uuid", Header: "Header", StartDate: start, Done: true, RRule: "FREQ=DAILY", Version: 4},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			tt.mockBehavior(mockTaskRepo)
+
+			taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: newAuditRepo(ctrl), tx: &inlineTx{}, now: func() time.Time { return start }}
+			task, err := taskUsecase.PatchTask(context.Background(), tt.task)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, task)
+		})
+	}
+}
+
+func TestTaskUsecase_BulkTasks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	done := true
+	tooMany := make([]int, maxBulkSize+1)
+	for i := range tooMany {
+		tooMany[i] = i + 1
+	}
+
+	type mockBehavior func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository)
+
+	tests := []struct {
+		name         string
+		bulk         *entity.Bulk
+		mockBehavior mockBehavior
+		want         *entity.BulkReport
+		wantErr      error
+	}{
+		{
+			name: "ids with foreign and missing tasks",
+			bulk: &entity.Bulk{Action: entity.BulkComplete, UserID: "uuid", IDs: []int{1, 2, 1, 3}},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {
+				r.EXPECT().Bulk(context.Background(), &entity.Bulk{Action: entity.BulkComplete, UserID: "uuid", IDs: []int{1, 2, 3}, At: now}).
+					Return([]entity.BulkResult{
+						{ID: 1, Status: entity.BulkOK, Task: &entity.Task{ID: 1, UserID: "uuid", Done: true}, Before: &entity.Task{ID: 1, UserID: "uuid"}},
+						{ID: 2, Status: entity.BulkForbidden},
+						{ID: 3, Status: entity.BulkNotFound},
+					}, nil, nil)
+				a.EXPECT().Create(context.Background(), gomock.Any()).Return(nil).Times(1)
+			},
+			want: &entity.BulkReport{Action: entity.BulkComplete, Succeeded: 1, Failed: 2, Results: []entity.BulkResult{
+				{ID: 1, Status: entity.BulkOK, Task: &entity.Task{ID: 1, UserID: "uuid", Done: true}, Before: &entity.Task{ID: 1, UserID: "uuid"}},
+				{ID: 2, Status: entity.BulkForbidden},
+				{ID: 3, Status: entity.BulkNotFound},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "filter",
+			bulk: &entity.Bulk{Action: entity.BulkDelete, UserID: "uuid", Query: &entity.TaskQuery{UserID: "uuid", Filter: entity.TaskFilter{Status: &done}}},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {
+				r.EXPECT().Find(context.Background(), &entity.TaskQuery{UserID: "uuid", Filter: entity.TaskFilter{Status: &done}, Page: 1, PageSize: maxBulkSize}).
+					Return([]entity.Task{{ID: 4}, {ID: 5}}, 2, false, nil)
+				r.EXPECT().Bulk(context.Background(), gomock.Any()).
+					Return([]entity.BulkResult{
+						{ID: 4, Status: entity.BulkOK, Before: &entity.Task{ID: 4, UserID: "uuid"}},
+						{ID: 5, Status: entity.BulkOK, Before: &entity.Task{ID: 5, UserID: "uuid"}},
+					}, []int{6}, nil)
+				// подзадача 6 удалена вместе с задачей 5 и тоже попадает в журнал
+				a.EXPECT().Create(context.Background(), gomock.Any()).Return(nil).Times(3)
+			},
+			want: &entity.BulkReport{Action: entity.BulkDelete, Succeeded: 2, Results: []entity.BulkResult{
+				{ID: 4, Status: entity.BulkOK, Before: &entity.Task{ID: 4, UserID: "uuid"}},
+				{ID: 5, Status: entity.BulkOK, Before: &entity.Task{ID: 5, UserID: "uuid"}},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "filter matches too many tasks",
+			bulk: &entity.Bulk{Action: entity.BulkDelete, UserID: "uuid", Query: &entity.TaskQuery{UserID: "uuid", Filter: entity.TaskFilter{Status: &done}}},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {
+				r.EXPECT().Find(context.Background(), gomock.Any()).Return([]entity.Task{{ID: 4}}, maxBulkSize+1, false, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrDataNotValid,
+		},
+		{
+			name:         "empty filter",
+			bulk:         &entity.Bulk{Action: entity.BulkDelete, UserID: "uuid", Query: &entity.TaskQuery{UserID: "uuid", Page: 2}},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "neither ids nor filter",
+			bulk:         &entity.Bulk{Action: entity.BulkDelete, UserID: "uuid"},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "too many ids",
+			bulk:         &entity.Bulk{Action: entity.BulkReopen, UserID: "uuid", IDs: tooMany},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "tags are required",
+			bulk:         &entity.Bulk{Action: entity.BulkAddTags, UserID: "uuid", IDs: []int{1}},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "unknown action",
+			bulk:         &entity.Bulk{Action: "archive", UserID: "uuid", IDs: []int{1}},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name: "completing recurring task spawns next occurrence",
+			bulk: &entity.Bulk{Action: entity.BulkComplete, UserID: "uuid", IDs: []int{1}},
+			mockBehavior: func(r *mock.MockTaskRepository, a *auditMock.MockAuditRepository) {
+				r.EXPECT().Bulk(context.Background(), gomock.Any()).
+					Return([]entity.BulkResult{
+						{ID: 1, Status: entity.BulkOK, Task: &entity.Task{ID: 1, UserID: "uuid", StartDate: now, Done: true, RRule: "FREQ=DAILY"},
+							Before: &entity.Task{ID: 1, UserID: "uuid", StartDate: now, RRule: "FREQ=DAILY"}},
+					}, nil, nil)
+				r.EXPECT().Create(context.Background(), gomock.Any()).Return(&entity.Task{ID: 2, UserID: "uuid"}, nil)
+				a.EXPECT().Create(context.Background(), gomock.Any()).Return(nil).Times(2)
+			},
+			want: &entity.BulkReport{Action: entity.BulkComplete, Succeeded: 1, Results: []entity.BulkResult{
+				{ID: 1, Status: entity.BulkOK, Task: &entity.Task{ID: 1, UserID: "uuid", StartDate: now, Done: true, RRule: "FREQ=DAILY"},
+					Before: &entity.Task{ID: 1, UserID: "uuid", StartDate: now, RRule: "FREQ=DAILY"}},
+			}},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTaskRepo := mock.NewMockTaskRepository(ctrl)
+			mockAuditRepo := auditMock.NewMockAuditRepository(ctrl)
+			tt.mockBehavior(mockTaskRepo, mockAuditRepo)
 
+			taskUsecase := &taskUsecase{taskRepo: mockTaskRepo, auditRepo: mockAuditRepo, tx: &inlineTx{}, now: func() time.Time { return now }}
+			report, err := taskUsecase.BulkTasks(context.Background(), tt.bulk)
 			assert.Equal(t, tt.wantErr, err)
-			assert.Equal(t, tt.want, tasks)
+			assert.Equal(t, tt.want, report)
 		})
 	}
 }
@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go-todolist-sber/internal/apperror"
+	pgxError "go-todolist-sber/internal/apperror/pgx_errors"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/token"
+	"go-todolist-sber/pkg/postgres"
+	"time"
+)
+
+const tokenColumns = `id, user_id, name, scope, prefix, token_hash, expires_at, last_used_at, created_at`
+
+type tokenRepository struct {
+	*postgres.Postgres
+}
+
+func NewTokenRepository(postgres *postgres.Postgres) token.TokenRepository {
+	return &tokenRepository{
+		postgres,
+	}
+}
+
+func tokenFields(token *entity.AccessToken) []interface{} {
+	return []interface{}{&token.ID, &token.UserID, &token.Name, &token.Scope, &token.Prefix, &token.Hash, &token.ExpiresAt,
+		&token.LastUsedAt, &token.CreatedAt}
+}
+
+func (t *tokenRepository) collectRow(row pgx.Row) (*entity.AccessToken, error) {
+	var token entity.AccessToken
+	err := row.Scan(tokenFields(&token)...)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	errCode := pgxError.ErrorCode(err)
+	if errCode == pgxError.ForeignKeyViolation {
+		return nil, apperror.ErrForeignKeyViolation
+	}
+	if errCode == pgxError.UniqueViolation {
+		return nil, apperror.ErrUniqueViolation
+	}
+	return &token, err
+}
+
+func (t *tokenRepository) Create(ctx context.Context, token *entity.AccessToken) (*entity.AccessToken, error) {
+	query := `insert into access_token (user_id, name, scope, prefix, token_hash, expires_at, created_at)
+				values ($1, $2, $3, $4, $5, $6, $7) returning ` + tokenColumns
+
+	row := t.Pool.QueryRow(ctx, query, token.UserID, token.Name, token.Scope, token.Prefix, token.Hash, token.ExpiresAt,
+		token.CreatedAt)
+	return t.collectRow(row)
+}
+
+// GetByHash возвращает токен по хешу вместе с ролью владельца
+func (t *tokenRepository) GetByHash(ctx context.Context, hash string) (*entity.AccessToken, error) {
+	query := `select t.id, t.user_id, t.name, t.scope, t.prefix, t.token_hash, t.expires_at, t.last_used_at, t.created_at, u.role
+				from access_token t
+				join "user" u on t.user_id = u.id
+				where t.token_hash = $1`
+
+	var token entity.AccessToken
+	err := t.Pool.QueryRow(ctx, query, hash).Scan(append(tokenFields(&token), &token.Role)...)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (t *tokenRepository) GetByUserID(ctx context.Context, userID string) ([]entity.AccessToken, error) {
+	query := `select ` + tokenColumns + ` from access_token where user_id = $1 order by created_at desc`
+
+	rows, err := t.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.AccessToken, error) {
+		token, err := t.collectRow(row)
+		if err != nil {
+			return entity.AccessToken{}, err
+		}
+		return *token, nil
+	})
+}
+
+// Touch отмечает время последнего запроса с токеном
+func (t *tokenRepository) Touch(ctx context.Context, id string, lastUsedAt time.Time) error {
+	query := `update access_token set last_used_at = $1 where id = $2`
+
+	_, err := t.Pool.Exec(ctx, query, lastUsedAt, id)
+	return err
+}
+
+// Delete отзывает токен id пользователя userID
+func (t *tokenRepository) Delete(ctx context.Context, id string, userID string) error {
+	query := `delete from access_token where id = $1 and user_id = $2`
+
+	tag, err := t.Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package token
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+	"time"
+)
+
+//go:generate mockgen -source storage.go -destination mock/token_repository_mock.go -package mock
+type TokenRepository interface {
+	Create(ctx context.Context, token *entity.AccessToken) (*entity.AccessToken, error)
+	GetByHash(ctx context.Context, hash string) (*entity.AccessToken, error)
+	GetByUserID(ctx context.Context, userID string) ([]entity.AccessToken, error)
+	Touch(ctx context.Context, id string, lastUsedAt time.Time) error
+	Delete(ctx context.Context, id string, userID string) error
+}
@@ -0,0 +1,13 @@
+package token
+
+import (
+	"context"
+	"go-todolist-sber/internal/entity"
+)
+
+type TokenUsecase interface {
+	CreateToken(ctx context.Context, token *entity.AccessToken) (*entity.NewAccessToken, error)
+	GetTokens(ctx context.Context, userID string) ([]entity.AccessToken, error)
+	DeleteToken(ctx context.Context, id string, userID string) error
+	Authenticate(ctx context.Context, secret string) (*entity.AccessToken, error)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/token"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// tokenPrefix отличает персональные токены от других секретов, например при поиске утечек в репозиториях
+	tokenPrefix = "tdl_"
+	// prefixLen — сколько первых символов токена хранится открыто и показывается в списке
+	prefixLen     = len(tokenPrefix) + 8
+	maxNameLen    = 100
+	lastUsedDelay = time.Minute
+)
+
+type tokenUsecase struct {
+	tokenRepo token.TokenRepository
+	random    io.Reader
+	now       func() time.Time
+}
+
+func NewTokenUsecase(tokenRepo token.TokenRepository) token.TokenUsecase {
+	return &tokenUsecase{
+		tokenRepo: tokenRepo,
+		random:    rand.Reader,
+		now:       time.Now,
+	}
+}
+
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateToken создает токен пользователя, секрет возвращается только в ответе на создание
+func (t *tokenUsecase) CreateToken(ctx context.Context, token *entity.AccessToken) (*entity.NewAccessToken, error) {
+	token.Name = strings.TrimSpace(token.Name)
+	if token.Name == "" || len(token.Name) > maxNameLen || !entity.IsTokenScopeValid(token.Scope) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	now := entity.WallClock(t.now())
+	if token.ExpiresAt != nil && !token.ExpiresAt.After(now) {
+		return nil, apperror.ErrDataNotValid
+	}
+
+	random := make([]byte, 32)
+	if _, err := io.ReadFull(t.random, random); err != nil {
+		return nil, err
+	}
+	secret := tokenPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	token.Prefix = secret[:prefixLen]
+	token.Hash = hashToken(secret)
+	token.CreatedAt = now
+
+	created, err := t.tokenRepo.Create(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.NewAccessToken{AccessToken: *created, Token: secret}, nil
+}
+
+func (t *tokenUsecase) GetTokens(ctx context.Context, userID string) ([]entity.AccessToken, error) {
+	return t.tokenRepo.GetByUserID(ctx, userID)
+}
+
+// DeleteToken отзывает токен, чужой токен неотличим от несуществующего
+func (t *tokenUsecase) DeleteToken(ctx context.Context, id string, userID string) error {
+	return t.tokenRepo.Delete(ctx, id, userID)
+}
+
+// Authenticate находит действующий токен по секрету из заголовка Authorization
+func (t *tokenUsecase) Authenticate(ctx context.Context, secret string) (*entity.AccessToken, error) {
+	if !strings.HasPrefix(secret, tokenPrefix) {
+		return nil, apperror.ErrInvalidToken
+	}
+
+	token, err := t.tokenRepo.GetByHash(ctx, hashToken(secret))
+	if errors.Is(err, apperror.ErrNoRows) {
+		return nil, apperror.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := entity.WallClock(t.now())
+	if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+		return nil, apperror.ErrInvalidToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedDelay {
+		if err := t.tokenRepo.Touch(ctx, token.ID, now); err != nil {
+			return nil, err
+		}
+		token.LastUsedAt = &now
+	}
+
+	return token, nil
+}
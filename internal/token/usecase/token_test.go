@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/internal/apperror"
+	"go-todolist-sber/internal/entity"
+	"go-todolist-sber/internal/token/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenUsecase_CreateToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	secret := "tdl_" + strings.Repeat("A", 43)
+
+	type mockBehavior func(r *mock.MockTokenRepository)
+
+	tests := []struct {
+		name         string
+		token        *entity.AccessToken
+		mockBehavior mockBehavior
+		want         *entity.NewAccessToken
+		wantErr      error
+	}{
+		{
+			name:  "ok",
+			token: &entity.AccessToken{UserID: "uuid", Name: " ci ", Scope: entity.TokenScopeRead},
+			mockBehavior: func(r *mock.MockTokenRepository) {
+				r.EXPECT().Create(context.Background(), &entity.AccessToken{UserID: "uuid", Name: "ci", Scope: entity.TokenScopeRead,
+					Prefix: "tdl_AAAAAAAA", Hash: hashToken(secret), CreatedAt: now}).
+					Return(&entity.AccessToken{ID: "id", UserID: "uuid", Name: "ci", Scope: entity.TokenScopeRead, Prefix: "tdl_AAAAAAAA"}, nil)
+			},
+			want: &entity.NewAccessToken{
+				AccessToken: entity.AccessToken{ID: "id", UserID: "uuid", Name: "ci", Scope: entity.TokenScopeRead, Prefix: "tdl_AAAAAAAA"},
+				Token:       secret,
+			},
+			wantErr: nil,
+		},
+		{
+			name:         "unknown scope",
+			token:        &entity.AccessToken{UserID: "uuid", Name: "ci", Scope: "admin"},
+			mockBehavior: func(r *mock.MockTokenRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "empty name",
+			token:        &entity.AccessToken{UserID: "uuid", Name: " ", Scope: entity.TokenScopeWrite},
+			mockBehavior: func(r *mock.MockTokenRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+		{
+			name:         "expiry in the past",
+			token:        &entity.AccessToken{UserID: "uuid", Name: "ci", Scope: entity.TokenScopeWrite, ExpiresAt: &past},
+			mockBehavior: func(r *mock.MockTokenRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrDataNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTokenRepo := mock.NewMockTokenRepository(ctrl)
+			tt.mockBehavior(mockTokenRepo)
+
+			tokenUsecase := &tokenUsecase{tokenRepo: mockTokenRepo, random: bytes.NewReader(make([]byte, 32)), now: func() time.Time { return now }}
+			token, err := tokenUsecase.CreateToken(context.Background(), tt.token)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, token)
+		})
+	}
+}
+
+func TestTokenUsecase_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Second)
+	stale := now.Add(-time.Hour)
+	secret := "tdl_secret"
+
+	type mockBehavior func(r *mock.MockTokenRepository)
+
+	tests := []struct {
+		name         string
+		secret       string
+		mockBehavior mockBehavior
+		want         *entity.AccessToken
+		wantErr      error
+	}{
+		{
+			name:   "ok",
+			secret: secret,
+			mockBehavior: func(r *mock.MockTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(&entity.AccessToken{ID: "id", LastUsedAt: &recent}, nil)
+			},
+			want:    &entity.AccessToken{ID: "id", LastUsedAt: &recent},
+			wantErr: nil,
+		},
+		{
+			name:   "last used is updated",
+			secret: secret,
+			mockBehavior: func(r *mock.MockTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(&entity.AccessToken{ID: "id", LastUsedAt: &stale}, nil)
+				r.EXPECT().Touch(context.Background(), "id", now).Return(nil)
+			},
+			want:    &entity.AccessToken{ID: "id", LastUsedAt: &now},
+			wantErr: nil,
+		},
+		{
+			name:   "expired",
+			secret: secret,
+			mockBehavior: func(r *mock.MockTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(&entity.AccessToken{ID: "id", ExpiresAt: &stale}, nil)
+			},
+			want:    nil,
+			wantErr: apperror.ErrInvalidToken,
+		},
+		{
+			name:   "unknown token",
+			secret: secret,
+			mockBehavior: func(r *mock.MockTokenRepository) {
+				r.EXPECT().GetByHash(context.Background(), hashToken(secret)).Return(nil, apperror.ErrNoRows)
+			},
+			want:    nil,
+			wantErr: apperror.ErrInvalidToken,
+		},
+		{
+			name:         "not an access token",
+			secret:       "session-token",
+			mockBehavior: func(r *mock.MockTokenRepository) {},
+			want:         nil,
+			wantErr:      apperror.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTokenRepo := mock.NewMockTokenRepository(ctrl)
+			tt.mockBehavior(mockTokenRepo)
+
+			tokenUsecase := &tokenUsecase{tokenRepo: mockTokenRepo, now: func() time.Time { return now }}
+			token, err := tokenUsecase.Authenticate(context.Background(), tt.secret)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, token)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+drop index if exists task_id_user_priority_idx;
+
+alter table task drop column priority;
+
+drop type priority;
@@ -0,0 +1,5 @@
+drop index if exists task_id_user_due_date_idx;
+
+alter table task drop column due_has_time;
+
+alter table task drop column due_date;
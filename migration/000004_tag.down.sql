@@ -0,0 +1,3 @@
+drop table if exists task_tag;
+
+drop table if exists tag;
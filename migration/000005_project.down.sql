@@ -0,0 +1,5 @@
+drop index if exists task_project_id_idx;
+
+alter table task drop column project_id;
+
+drop table if exists project;
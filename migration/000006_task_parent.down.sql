@@ -0,0 +1,3 @@
+drop index if exists task_parent_id_idx;
+
+alter table task drop column parent_id;
@@ -0,0 +1,7 @@
+drop index if exists task_series_id_occurrence_idx;
+
+alter table task drop column occurrence;
+
+alter table task drop column series_id;
+
+alter table task drop column rrule;
@@ -0,0 +1,3 @@
+drop table if exists reminder;
+
+drop type if exists reminder_anchor;
@@ -0,0 +1,3 @@
+drop index if exists task_search_idx;
+
+alter table task drop column search;
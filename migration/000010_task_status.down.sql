@@ -0,0 +1,7 @@
+drop index if exists task_status_id_idx;
+
+alter table task drop column status_id;
+
+drop table if exists status_transition;
+
+drop table if exists status;
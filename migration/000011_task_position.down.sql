@@ -0,0 +1,3 @@
+drop index if exists task_id_user_position_idx;
+
+alter table task drop column position;
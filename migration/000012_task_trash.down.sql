@@ -0,0 +1,5 @@
+drop index if exists task_deleted_at_idx;
+
+delete from task where deleted_at is not null;
+
+alter table task drop column deleted_at;
@@ -0,0 +1,9 @@
+drop index if exists task_audit_created_at_idx;
+
+drop index if exists task_audit_actor_idx;
+
+drop index if exists task_audit_id_user_idx;
+
+drop index if exists task_audit_task_id_idx;
+
+drop table if exists task_audit;
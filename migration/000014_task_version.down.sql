@@ -0,0 +1 @@
+alter table task drop column version;
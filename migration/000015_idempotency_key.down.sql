@@ -0,0 +1,3 @@
+drop index if exists idempotency_key_expires_at_idx;
+
+drop table if exists idempotency_key;
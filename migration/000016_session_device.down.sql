@@ -0,0 +1,13 @@
+drop index if exists session_user_id_idx;
+
+drop index if exists session_id_idx;
+
+alter table session drop column if exists last_seen_at;
+
+alter table session drop column if exists created_at;
+
+alter table session drop column if exists ip;
+
+alter table session drop column if exists user_agent;
+
+alter table session drop column if exists id;
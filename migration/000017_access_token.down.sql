@@ -0,0 +1,5 @@
+drop index if exists access_token_user_id_idx;
+
+drop index if exists access_token_hash_idx;
+
+drop table if exists access_token;
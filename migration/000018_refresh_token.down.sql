@@ -0,0 +1,7 @@
+drop index if exists refresh_token_expires_at_idx;
+
+drop index if exists refresh_token_family_id_idx;
+
+drop index if exists refresh_token_hash_idx;
+
+drop table if exists refresh_token;
@@ -0,0 +1,5 @@
+create type priority as enum ('none','low','medium','high','urgent');
+
+alter table task add column priority priority not null default 'none';
+
+create index if not exists task_id_user_priority_idx on task (id_user, priority);
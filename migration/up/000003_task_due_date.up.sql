@@ -0,0 +1,5 @@
+alter table task add column due_date timestamp;
+
+alter table task add column due_has_time bool not null default false;
+
+create index if not exists task_id_user_due_date_idx on task (id_user, due_date);
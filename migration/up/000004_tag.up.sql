@@ -0,0 +1,22 @@
+create table if not exists tag(
+    id int generated always as identity,
+    id_user uuid not null,
+    name varchar(50) not null,
+    created_at timestamp default current_timestamp not null,
+    primary key (id),
+    unique (id_user, name),
+    foreign key (id_user)
+            references "user" (id) on delete cascade
+);
+
+create table if not exists task_tag(
+    task_id int not null,
+    tag_id int not null,
+    primary key (task_id, tag_id),
+    foreign key (task_id)
+            references task (id) on delete cascade,
+    foreign key (tag_id)
+            references tag (id) on delete cascade
+);
+
+create index if not exists task_tag_tag_id_idx on task_tag (tag_id);
@@ -0,0 +1,16 @@
+create table if not exists project(
+    id int generated always as identity,
+    id_user uuid not null,
+    name varchar(100) not null,
+    archived bool not null default false,
+    created_at timestamp default current_timestamp not null,
+    primary key (id),
+    foreign key (id_user)
+            references "user" (id) on delete cascade
+);
+
+create index if not exists project_id_user_idx on project (id_user);
+
+alter table task add column project_id int references project (id) on delete set null;
+
+create index if not exists task_project_id_idx on task (project_id);
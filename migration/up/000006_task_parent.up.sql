@@ -0,0 +1,3 @@
+alter table task add column parent_id int references task (id) on delete cascade;
+
+create index if not exists task_parent_id_idx on task (parent_id);
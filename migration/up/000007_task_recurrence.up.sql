@@ -0,0 +1,7 @@
+alter table task add column rrule text not null default '';
+
+alter table task add column series_id int;
+
+alter table task add column occurrence int not null default 1;
+
+create unique index if not exists task_series_id_occurrence_idx on task (series_id, occurrence);
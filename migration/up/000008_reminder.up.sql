@@ -0,0 +1,23 @@
+create type reminder_anchor as enum ('start','due');
+
+create table if not exists reminder(
+    id int generated always as identity,
+    task_id int not null,
+    id_user uuid not null,
+    remind_at timestamp,
+    anchor reminder_anchor,
+    offset_minutes int not null default 0,
+    attempts int not null default 0,
+    claimed_until timestamp,
+    sent_at timestamp,
+    created_at timestamp default current_timestamp not null,
+    primary key (id),
+    check ((remind_at is null) <> (anchor is null)),
+    foreign key (task_id)
+            references task (id) on delete cascade,
+    foreign key (id_user)
+            references "user" (id) on delete cascade
+);
+
+create index if not exists reminder_task_id_idx on reminder (task_id);
+create index if not exists reminder_pending_idx on reminder (id) where sent_at is null;
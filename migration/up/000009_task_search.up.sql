@@ -0,0 +1,6 @@
+alter table task add column search tsvector generated always as (
+    setweight(to_tsvector('simple', header), 'A') ||
+    setweight(to_tsvector('simple', description), 'B')
+) stored;
+
+create index if not exists task_search_idx on task using gin (search);
@@ -0,0 +1,30 @@
+create table if not exists status(
+    id int generated always as identity,
+    id_user uuid not null,
+    project_id int,
+    name varchar(50) not null,
+    position int not null default 0,
+    done bool not null default false,
+    created_at timestamp default current_timestamp not null,
+    primary key (id),
+    foreign key (id_user)
+            references "user" (id) on delete cascade,
+    foreign key (project_id)
+            references project (id) on delete cascade
+);
+
+create unique index if not exists status_scope_name_idx on status (id_user, coalesce(project_id, 0), lower(name));
+
+create table if not exists status_transition(
+    from_status_id int not null,
+    to_status_id int not null,
+    primary key (from_status_id, to_status_id),
+    foreign key (from_status_id)
+            references status (id) on delete cascade,
+    foreign key (to_status_id)
+            references status (id) on delete cascade
+);
+
+alter table task add column status_id int references status (id) on delete set null;
+
+create index if not exists task_status_id_idx on task (status_id);
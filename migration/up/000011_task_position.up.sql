@@ -0,0 +1,11 @@
+alter table task add column position varchar(255) collate "C";
+
+-- Ручной порядок начинается с порядка по умолчанию: сначала новые задачи
+update task set position = ranked.position
+    from (select id, lpad((row_number() over (partition by id_user order by id desc))::text, 10, '0') || 'i' as position
+            from task) ranked
+    where task.id = ranked.id;
+
+alter table task alter column position set not null;
+
+create index if not exists task_id_user_position_idx on task (id_user, position);
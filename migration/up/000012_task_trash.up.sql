@@ -0,0 +1,3 @@
+alter table task add column deleted_at timestamp;
+
+create index if not exists task_deleted_at_idx on task (deleted_at) where deleted_at is not null;
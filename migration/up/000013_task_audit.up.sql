@@ -0,0 +1,20 @@
+create table if not exists task_audit(
+    id bigint generated always as identity,
+    task_id int not null,
+    id_user uuid not null,
+    actor varchar(255) not null default '',
+    action varchar(20) not null,
+    before jsonb,
+    after jsonb,
+    request_id varchar(255) not null default '',
+    created_at timestamp default current_timestamp not null,
+    primary key (id)
+);
+
+create index if not exists task_audit_task_id_idx on task_audit (task_id, id);
+
+create index if not exists task_audit_id_user_idx on task_audit (id_user, created_at);
+
+create index if not exists task_audit_actor_idx on task_audit (actor, created_at);
+
+create index if not exists task_audit_created_at_idx on task_audit (created_at);
@@ -0,0 +1 @@
+alter table task add column version int not null default 1;
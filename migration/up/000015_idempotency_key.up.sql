@@ -0,0 +1,13 @@
+create table if not exists idempotency_key(
+    id_user uuid not null,
+    key varchar(255) not null,
+    request_hash varchar(64) not null,
+    status_code int not null default 0,
+    header jsonb,
+    response bytea,
+    created_at timestamp default current_timestamp not null,
+    expires_at timestamp not null,
+    primary key (id_user, key)
+);
+
+create index if not exists idempotency_key_expires_at_idx on idempotency_key (expires_at);
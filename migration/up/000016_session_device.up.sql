@@ -0,0 +1,13 @@
+alter table session add column if not exists id uuid not null default uuid_generate_v4();
+
+alter table session add column if not exists user_agent varchar(512) not null default '';
+
+alter table session add column if not exists ip varchar(64) not null default '';
+
+alter table session add column if not exists created_at timestamp not null default current_timestamp;
+
+alter table session add column if not exists last_seen_at timestamp not null default current_timestamp;
+
+create unique index if not exists session_id_idx on session (id);
+
+create index if not exists session_user_id_idx on session (user_id);
@@ -0,0 +1,18 @@
+create table if not exists access_token(
+    id uuid default uuid_generate_v4(),
+    user_id uuid not null,
+    name varchar(100) not null,
+    scope varchar(10) not null,
+    prefix varchar(16) not null,
+    token_hash varchar(64) not null,
+    expires_at timestamp,
+    last_used_at timestamp,
+    created_at timestamp default current_timestamp not null,
+    primary key (id),
+    foreign key (user_id)
+        references "user" (id) on delete cascade
+);
+
+create unique index if not exists access_token_hash_idx on access_token (token_hash);
+
+create index if not exists access_token_user_id_idx on access_token (user_id);
@@ -0,0 +1,21 @@
+create table if not exists refresh_token(
+    id uuid default uuid_generate_v4(),
+    family_id uuid not null,
+    user_id uuid not null,
+    token_hash varchar(64) not null,
+    user_agent varchar(512) not null default '',
+    ip varchar(64) not null default '',
+    expires_at timestamp not null,
+    used_at timestamp,
+    family_created_at timestamp not null,
+    created_at timestamp default current_timestamp not null,
+    primary key (id),
+    foreign key (user_id)
+        references "user" (id) on delete cascade
+);
+
+create unique index if not exists refresh_token_hash_idx on refresh_token (token_hash);
+
+create index if not exists refresh_token_family_id_idx on refresh_token (family_id);
+
+create index if not exists refresh_token_expires_at_idx on refresh_token (expires_at);
@@ -0,0 +1,338 @@
+// Package jsonpatch применяет к JSON-документу изменения в форматах JSON Merge Patch (RFC 7396)
+// и JSON Patch (RFC 6902). Документ и патч передаются как JSON, числа не теряют точности
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch — патч не является корректным документом своего формата
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPathNotFound — операция JSON Patch ссылается на отсутствующее значение
+	ErrPathNotFound = errors.New("path not found")
+	// ErrTestFailed — операция test не совпала с документом
+	ErrTestFailed = errors.New("test operation failed")
+)
+
+// MergePatch применяет JSON Merge Patch: поля патча заменяют поля документа, null удаляет поле,
+// вложенные объекты объединяются рекурсивно, а массивы заменяются целиком
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := decode(patch)
+	if err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	return json.Marshal(merge(target, changes))
+}
+
+func merge(target, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{}, len(changes))
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = merge(object[name], value)
+	}
+	return object
+}
+
+type operation struct {
+	Op   string  `json:"op"`
+	Path *string `json:"path"`
+	From *string `json:"from"`
+	// Value хранит null как литерал, чтобы отличить его от отсутствующего значения
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply применяет JSON Patch — последовательность операций add, remove, replace, move, copy и test.
+// Операции выполняются по порядку, ошибка любой из них отменяет весь патч
+func Apply(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	for i, op := range operations {
+		if op.Path == nil {
+			return nil, fmt.Errorf("operation %d: %w", i, ErrInvalidPatch)
+		}
+		path, err := parsePointer(*op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+
+		target, err = apply(target, op, path)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func apply(target interface{}, op operation, path []string) (interface{}, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, ErrInvalidPatch
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, ErrInvalidPatch
+		}
+
+		switch op.Op {
+		case "add":
+			return add(target, path, value)
+		case "replace":
+			if target, _, err = remove(target, path); err != nil {
+				return nil, err
+			}
+			return add(target, path, value)
+		}
+
+		current, err := get(target, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, ErrTestFailed
+		}
+		return target, nil
+	case "remove":
+		target, _, err := remove(target, path)
+		return target, err
+	case "move", "copy":
+		if op.From == nil {
+			return nil, ErrInvalidPatch
+		}
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		if op.Op == "move" {
+			// значение нельзя перенести внутрь самого себя
+			if len(path) > len(from) && isPrefix(from, path) {
+				return nil, ErrInvalidPatch
+			}
+			if target, value, err = remove(target, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = get(target, from); err != nil {
+				return nil, err
+			}
+			value = clone(value)
+		}
+		return add(target, path, value)
+	}
+
+	return nil, ErrInvalidPatch
+}
+
+// add вставляет value по пути path и возвращает новый корень документа
+func add(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(target, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[last] = value
+		return target, nil
+	case []interface{}:
+		index := len(container)
+		if last != "-" {
+			if index, err = arrayIndex(last, len(container)); err != nil {
+				return nil, err
+			}
+		}
+		updated := append(container[:index:index], append([]interface{}{value}, container[index:]...)...)
+		return set(target, path[:len(path)-1], updated)
+	}
+	return nil, ErrPathNotFound
+}
+
+// remove удаляет значение по пути path и возвращает новый корень документа и удаленное значение
+func remove(target interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, target, nil
+	}
+
+	parent, err := get(target, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		value, ok := container[last]
+		if !ok {
+			return nil, nil, ErrPathNotFound
+		}
+		delete(container, last)
+		return target, value, nil
+	case []interface{}:
+		index, err := arrayIndex(last, len(container)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := container[index]
+		updated := append(container[:index:index], container[index+1:]...)
+		target, err = set(target, path[:len(path)-1], updated)
+		return target, value, err
+	}
+	return nil, nil, ErrPathNotFound
+}
+
+// set заменяет значение по пути path, нужен для массивов, которые при изменении длины создаются заново
+func set(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(target, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[last] = value
+	case []interface{}:
+		index, err := arrayIndex(last, len(container)-1)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = value
+	default:
+		return nil, ErrPathNotFound
+	}
+	return target, nil
+}
+
+func get(target interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := target.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			target = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			target = container[index]
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+	return target, nil
+}
+
+// arrayIndex разбирает индекс массива, допустимы значения от 0 до max включительно
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || len(token) > 1 && token[0] == '0' {
+		return 0, ErrPathNotFound
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, ErrPathNotFound
+	}
+	return index, nil
+}
+
+// parsePointer разбирает JSON Pointer (RFC 6901), пустая строка указывает на весь документ
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, ErrInvalidPatch
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func clone(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for name, item := range v {
+			copied[name] = clone(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = clone(item)
+		}
+		return copied
+	}
+	return value
+}
+
+func decode(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var value interface{}
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+	if d.More() {
+		return nil, ErrInvalidPatch
+	}
+	return value, nil
+}
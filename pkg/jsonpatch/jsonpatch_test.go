@@ -0,0 +1,77 @@
+package jsonpatch
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{name: "replace field", doc: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add field", doc: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "null removes field", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "array is replaced", doc: `{"a":[1,2]}`, patch: `{"a":[3]}`, want: `{"a":[3]}`},
+		{name: "nested objects are merged", doc: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"b":null,"f":"g"}}`, want: `{"a":{"d":"e","f":"g"}}`},
+		{name: "not an object replaces document", doc: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+		{name: "large number keeps precision", doc: `{"a":1}`, patch: `{"a":9007199254740993}`, want: `{"a":9007199254740993}`},
+		{name: "invalid patch", doc: `{"a":"b"}`, patch: `{"a":`, wantErr: ErrInvalidPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{name: "add field", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux"}]`, want: `{"foo":"bar","baz":"qux"}`},
+		{name: "add to array", doc: `{"foo":["bar","baz"]}`, patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`, want: `{"foo":["bar","qux","baz"]}`},
+		{name: "append to array", doc: `{"foo":[1]}`, patch: `[{"op":"add","path":"/foo/-","value":2}]`, want: `{"foo":[1,2]}`},
+		{name: "remove field", doc: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, want: `{"foo":"bar"}`},
+		{name: "remove from array", doc: `{"foo":["bar","qux","baz"]}`, patch: `[{"op":"remove","path":"/foo/1"}]`, want: `{"foo":["bar","baz"]}`},
+		{name: "replace field", doc: `{"baz":"qux"}`, patch: `[{"op":"replace","path":"/baz","value":null}]`, want: `{"baz":null}`},
+		{name: "move field", doc: `{"foo":{"bar":"baz"},"qux":{}}`, patch: `[{"op":"move","from":"/foo/bar","path":"/qux/thud"}]`, want: `{"foo":{},"qux":{"thud":"baz"}}`},
+		{name: "copy field", doc: `{"foo":[1]}`, patch: `[{"op":"copy","from":"/foo","path":"/bar"}]`, want: `{"foo":[1],"bar":[1]}`},
+		{name: "escaped pointer", doc: `{"a/b":1,"m~n":2}`, patch: `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, want: `{"a/b":3}`},
+		{name: "test passes", doc: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"remove","path":"/baz"}]`, want: `{}`},
+		{name: "test fails", doc: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, wantErr: ErrTestFailed},
+		{name: "replace missing field", doc: `{}`, patch: `[{"op":"replace","path":"/baz","value":1}]`, wantErr: ErrPathNotFound},
+		{name: "index out of range", doc: `{"foo":[1]}`, patch: `[{"op":"add","path":"/foo/3","value":2}]`, wantErr: ErrPathNotFound},
+		{name: "move into itself", doc: `{"foo":{}}`, patch: `[{"op":"move","from":"/foo","path":"/foo/bar"}]`, wantErr: ErrInvalidPatch},
+		{name: "unknown operation", doc: `{}`, patch: `[{"op":"merge","path":"/foo"}]`, wantErr: ErrInvalidPatch},
+		{name: "not an array", doc: `{}`, patch: `{"op":"add"}`, wantErr: ErrInvalidPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
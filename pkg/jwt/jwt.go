@@ -0,0 +1,211 @@
+// Package jwt выпускает и проверяет JWT, подписанные Ed25519 (алгоритм EdDSA, RFC 8037).
+// Ключи собираются в набор: первым ключом подписываются новые токены, а проверка принимает
+// любой ключ набора, поэтому ключи можно менять без разлогинивания пользователей
+package jwt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const algorithm = "EdDSA"
+
+var (
+	// ErrInvalidKey — ключ задан в неверном формате или его идентификатор повторяется
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrMalformed — строка не является JWT или заголовок не поддерживается
+	ErrMalformed = errors.New("malformed token")
+	// ErrUnknownKey — токен подписан ключом, которого нет в наборе
+	ErrUnknownKey = errors.New("unknown signing key")
+	// ErrSignature — подпись не совпала
+	ErrSignature = errors.New("invalid signature")
+	// ErrExpired — срок действия токена истек
+	ErrExpired = errors.New("token expired")
+)
+
+// Claims — поля токена, времена хранятся в секундах Unix
+type Claims struct {
+	Issuer    string `json:"iss,omitempty"`
+	Subject   string `json:"sub"`
+	Role      string `json:"role,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid"`
+}
+
+// Key — ключ подписи с идентификатором kid, который попадает в заголовок токена
+type Key struct {
+	ID      string
+	Private ed25519.PrivateKey
+}
+
+// GenerateKey создает случайный ключ
+func GenerateKey(id string) (Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{ID: id, Private: private}, nil
+}
+
+// ParseKey разбирает ключ вида kid:seed, где seed — 32 байта в base64url без выравнивания
+func ParseKey(spec string) (Key, error) {
+	id, encoded, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok || id == "" {
+		return Key{}, ErrInvalidKey
+	}
+	seed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return Key{}, ErrInvalidKey
+	}
+	return Key{ID: id, Private: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// KeySet — набор ключей, первый ключ подписывает новые токены
+type KeySet struct {
+	keys []Key
+}
+
+func NewKeySet(keys ...Key) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.ID == "" || seen[key.ID] || len(key.Private) != ed25519.PrivateKeySize {
+			return nil, ErrInvalidKey
+		}
+		seen[key.ID] = true
+	}
+	return &KeySet{keys: keys}, nil
+}
+
+// Sign подписывает claims текущим ключом
+func (k *KeySet) Sign(claims Claims) (string, error) {
+	key := k.keys[0]
+
+	head, err := encodeSegment(header{Alg: algorithm, Typ: "JWT", Kid: key.ID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := head + "." + payload
+	signature := ed25519.Sign(key.Private, []byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify проверяет подпись и срок действия токена на момент now
+func (k *KeySet) Verify(token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil {
+		return nil, ErrMalformed
+	}
+	// алгоритм задан сервером, значение из заголовка только сверяется с ним
+	if head.Alg != algorithm {
+		return nil, ErrMalformed
+	}
+
+	key, ok := k.find(head.Kid)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if !ed25519.Verify(key.Private.Public().(ed25519.PublicKey), []byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrSignature
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformed
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpired
+	}
+	return &claims, nil
+}
+
+func (k *KeySet) find(id string) (Key, bool) {
+	for _, key := range k.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// JWK — открытый ключ в формате RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	X   string `json:"x"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает открытые ключи набора, по ним токены могут проверять другие сервисы
+func (k *KeySet) JWKS() JWKS {
+	keys := make([]JWK, 0, len(k.keys))
+	for _, key := range k.keys {
+		keys = append(keys, JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			Kid: key.ID,
+			Use: "sig",
+			Alg: algorithm,
+			X:   base64.RawURLEncoding.EncodeToString(key.Private.Public().(ed25519.PublicKey)),
+		})
+	}
+	return JWKS{Keys: keys}
+}
+
+func encodeSegment(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(segment string, value interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	if err := d.Decode(value); err != nil {
+		return fmt.Errorf("decode segment: %w", err)
+	}
+	return nil
+}
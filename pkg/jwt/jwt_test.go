@@ -0,0 +1,127 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKey(t *testing.T, id string, fill byte) Key {
+	seed := base64.RawURLEncoding.EncodeToString([]byte(strings.Repeat(string(fill), 32)))
+	key, err := ParseKey(id + ":" + seed)
+	require.NoError(t, err)
+	return key
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "ok", spec: "2024-03:" + base64.RawURLEncoding.EncodeToString(make([]byte, 32))},
+		{name: "without id", spec: ":" + base64.RawURLEncoding.EncodeToString(make([]byte, 32)), wantErr: true},
+		{name: "without seed", spec: "2024-03", wantErr: true},
+		{name: "short seed", spec: "2024-03:" + base64.RawURLEncoding.EncodeToString(make([]byte, 16)), wantErr: true},
+		{name: "not base64", spec: "2024-03:***", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKey(tt.spec)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidKey)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "2024-03", key.ID)
+		})
+	}
+}
+
+func TestNewKeySet(t *testing.T) {
+	_, err := NewKeySet()
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = NewKeySet(testKey(t, "a", 1), testKey(t, "a", 2))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestKeySet_Verify(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	claims := Claims{Issuer: "todolist", Subject: "uuid", Role: "user", IssuedAt: now.Unix(), ExpiresAt: now.Add(15 * time.Minute).Unix()}
+
+	current := testKey(t, "current", 1)
+	previous := testKey(t, "previous", 2)
+
+	rotated, err := NewKeySet(current, previous)
+	require.NoError(t, err)
+	old, err := NewKeySet(previous)
+	require.NoError(t, err)
+	foreign, err := NewKeySet(testKey(t, "current", 3))
+	require.NoError(t, err)
+
+	sign := func(keys *KeySet) string {
+		token, err := keys.Sign(claims)
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "signed with current key", token: sign(rotated), now: now},
+		{name: "signed with previous key", token: sign(old), now: now},
+		{name: "expired", token: sign(rotated), now: now.Add(15 * time.Minute), wantErr: ErrExpired},
+		{name: "unknown key", token: sign(mustKeySet(t, testKey(t, "retired", 4))), now: now, wantErr: ErrUnknownKey},
+		{name: "same kid, other key", token: sign(foreign), now: now, wantErr: ErrSignature},
+		{name: "tampered payload", token: tamper(t, sign(rotated)), now: now, wantErr: ErrSignature},
+		{name: "alg none", token: "eyJhbGciOiJub25lIiwia2lkIjoiY3VycmVudCJ9." + strings.Split(sign(rotated), ".")[1] + ".", now: now, wantErr: ErrMalformed},
+		{name: "not a jwt", token: "tdl_secret", now: now, wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rotated.Verify(tt.token, tt.now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &claims, got)
+		})
+	}
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	keys := mustKeySet(t, testKey(t, "current", 1), testKey(t, "previous", 2))
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "current", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.Len(t, jwks.Keys[0].X, 43)
+}
+
+func mustKeySet(t *testing.T, keys ...Key) *KeySet {
+	set, err := NewKeySet(keys...)
+	require.NoError(t, err)
+	return set
+}
+
+// tamper подменяет sub, оставляя подпись исходного токена
+func tamper(t *testing.T, token string) string {
+	parts := strings.Split(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(payload), `"sub":"uuid"`, `"sub":"other"`, 1)))
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,84 @@
+// Package lexorank строит строковые ключи ручного порядка. Ключи сравниваются побайтно,
+// и между любыми двумя ключами всегда есть еще один, поэтому перемещение элемента меняет только его ключ
+package lexorank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalidRank = errors.New("invalid rank")
+
+// Between возвращает ключ строго между a и b. Пустой a означает начало списка, пустой b — конец.
+// Ключи должны состоять из цифр и строчных латинских букв и не заканчиваться на 0
+func Between(a, b string) (string, error) {
+	if !isValid(a) || !isValid(b) {
+		return "", ErrInvalidRank
+	}
+	if b != "" && a >= b {
+		return "", ErrInvalidRank
+	}
+	return midpoint(a, b), nil
+}
+
+// After возвращает ключ после a
+func After(a string) (string, error) {
+	return Between(a, "")
+}
+
+// Before возвращает ключ перед b
+func Before(b string) (string, error) {
+	return Between("", b)
+}
+
+// midpoint рассматривает ключи как дробные числа по основанию 36 после запятой, пустой b равен единице
+func midpoint(a, b string) string {
+	n := 0
+	for n < len(b) && digitAt(a, n) == strings.IndexByte(digits, b[n]) {
+		n++
+	}
+	if n > 0 {
+		rest := ""
+		if n < len(a) {
+			rest = a[n:]
+		}
+		return b[:n] + midpoint(rest, b[n:])
+	}
+
+	low := digitAt(a, 0)
+	high := len(digits)
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+
+	// Соседние цифры: первая цифра b уже больше a, иначе продолжаем после первой цифры a
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[low]) + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	return strings.IndexByte(digits, s[i])
+}
+
+func isValid(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(digits, s[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(s, "0")
+}
@@ -0,0 +1,66 @@
+package lexorank
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty list", a: "", b: "", want: "i"},
+		{name: "after", a: "i", b: "", want: "r"},
+		{name: "before", a: "", b: "i", want: "9"},
+		{name: "middle", a: "a", b: "c", want: "b"},
+		{name: "adjacent digits", a: "a", b: "b", want: "ai"},
+		{name: "common prefix", a: "a1", b: "a3", want: "a2"},
+		{name: "prefix of b", a: "a", b: "a1", want: "a0i"},
+		{name: "before smallest", a: "", b: "1", want: "0i"},
+		{name: "after largest digit", a: "z", b: "", want: "zi"},
+		{name: "wrong order", a: "c", b: "a", wantErr: true},
+		{name: "equal", a: "a", b: "a", wantErr: true},
+		{name: "trailing zero", a: "a0", b: "", wantErr: true},
+		{name: "invalid char", a: "A", b: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.a, tt.b)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRank)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Greater(t, got, tt.a)
+			if tt.b != "" {
+				assert.Less(t, got, tt.b)
+			}
+		})
+	}
+}
+
+func TestBetween_Repeated(t *testing.T) {
+	// Многократная вставка в одно место не должна приводить к совпадению ключей
+	low, high := "a", "b"
+	for i := 0; i < 100; i++ {
+		mid, err := Between(low, high)
+		require.NoError(t, err)
+		require.Greater(t, mid, low)
+		require.Less(t, mid, high)
+		high = mid
+	}
+
+	rank := ""
+	for i := 0; i < 100; i++ {
+		next, err := After(rank)
+		require.NoError(t, err)
+		require.Greater(t, next, rank)
+		rank = next
+	}
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"log"
 	"time"
@@ -11,6 +13,57 @@ type Postgres struct {
 	Pool *pgxpool.Pool
 }
 
+// Querier позволяет выполнять одни и те же запросы как через пул, так и внутри транзакции
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Transactor выполняет fn в одной транзакции. Репозитории, вызванные из fn с переданным ей ctx,
+// работают внутри этой транзакции
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// WithinTx выполняет fn в транзакции и фиксирует ее, если fn не вернула ошибку.
+// Внутри уже открытой транзакции fn выполняется в ней же
+func (p *Postgres) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Begin начинает транзакцию, внутри WithinTx — вложенную транзакцию на точке сохранения,
+// откат которой не отменяет внешнюю транзакцию
+func (p *Postgres) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return p.Pool.Begin(ctx)
+}
+
+// Conn возвращает транзакцию из ctx, а вне WithinTx — пул
+func (p *Postgres) Conn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return p.Pool
+}
+
 func (p *Postgres) Close() {
 	if p.Pool != nil {
 		p.Pool.Close()
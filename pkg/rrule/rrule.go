@@ -0,0 +1,279 @@
+// Package rrule реализует подмножество правил повторения RFC 5545,
+// достаточное для повторяющихся задач: FREQ, INTERVAL, COUNT, UNTIL, BYDAY и BYMONTHDAY
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+const (
+	prefix      = "RRULE:"
+	maxInterval = 365
+)
+
+var (
+	ErrInvalidRule = errors.New("invalid rrule")
+
+	weekdays = map[string]time.Weekday{
+		"MO": time.Monday,
+		"TU": time.Tuesday,
+		"WE": time.Wednesday,
+		"TH": time.Thursday,
+		"FR": time.Friday,
+		"SA": time.Saturday,
+		"SU": time.Sunday,
+	}
+)
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+// Parse разбирает строку правила, префикс "RRULE:" необязателен
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+		value = value[len(prefix):]
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(val))
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && (rule.Interval < 1 || rule.Interval > maxInterval) {
+				err = fmt.Errorf("interval out of range")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err == nil && rule.Count < 1 {
+				err = fmt.Errorf("count must be positive")
+			}
+		case "UNTIL":
+			rule.Until, err = parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(val)
+		default:
+			err = fmt.Errorf("unsupported part %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	switch rule.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, rule.Freq)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported UNTIL %q", value)
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, day := range strings.Split(value, ",") {
+		weekday, ok := weekdays[strings.ToUpper(day)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported BYDAY %q", day)
+		}
+		days = append(days, weekday)
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, day := range strings.Split(value, ",") {
+		monthDay, err := strconv.Atoi(day)
+		if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+			return nil, fmt.Errorf("unsupported BYMONTHDAY %q", day)
+		}
+		days = append(days, monthDay)
+	}
+	return days, nil
+}
+
+// String возвращает правило в каноническом виде без префикса "RRULE:"
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405Z"))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			for name, day := range weekdays {
+				if day == weekday {
+					days = append(days, name)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next возвращает следующее после after повторение серии, начатой в dtstart.
+// occurrence — порядковый номер повторения after в серии, начиная с 1.
+// Время суток всех повторений совпадает с dtstart, ok равен false, если серия закончилась
+func (r *Rule) Next(dtstart, after time.Time, occurrence int) (next time.Time, ok bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	start := date(dtstart)
+	day := date(after).AddDate(0, 0, 1)
+	if day.Before(start) {
+		day = start
+	}
+
+	// Любое правило повторяется не реже раза в 8 периодов: этого достаточно даже для 29 февраля
+	limit := day.AddDate(8*r.Interval, 0, 0)
+	for ; day.Before(limit); day = day.AddDate(0, 0, 1) {
+		if !r.matches(start, day) {
+			continue
+		}
+
+		next = time.Date(day.Year(), day.Month(), day.Day(),
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+		if !r.Until.IsZero() && next.After(r.Until) {
+			return time.Time{}, false
+		}
+		return next, true
+	}
+
+	return time.Time{}, false
+}
+
+func (r *Rule) matches(start, day time.Time) bool {
+	switch r.Freq {
+	case Daily:
+		if daysBetween(start, day)%r.Interval != 0 {
+			return false
+		}
+		return r.matchesByDay(day, true) && r.matchesByMonthDay(day, true)
+	case Weekly:
+		if daysBetween(weekStart(start), weekStart(day))/7%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		return r.matchesByDay(day, false)
+	case Monthly:
+		months := (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			return day.Day() == start.Day()
+		}
+		return r.matchesByDay(day, true) && r.matchesByMonthDay(day, true)
+	case Yearly:
+		if (day.Year()-start.Year())%r.Interval != 0 {
+			return false
+		}
+		return day.Month() == start.Month() && day.Day() == start.Day()
+	}
+	return false
+}
+
+func (r *Rule) matchesByDay(day time.Time, emptyMatches bool) bool {
+	if len(r.ByDay) == 0 {
+		return emptyMatches
+	}
+	for _, weekday := range r.ByDay {
+		if day.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesByMonthDay(day time.Time, emptyMatches bool) bool {
+	if len(r.ByMonthDay) == 0 {
+		return emptyMatches
+	}
+	lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay < 0 {
+			monthDay = lastDay + monthDay + 1
+		}
+		if day.Day() == monthDay {
+			return true
+		}
+	}
+	return false
+}
+
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,120 @@
+package rrule
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "daily", value: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefix and interval", value: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{name: "until date", value: "FREQ=MONTHLY;BYMONTHDAY=-1;UNTIL=20231231", want: "FREQ=MONTHLY;UNTIL=20231231T235959Z;BYMONTHDAY=-1"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "unknown freq", value: "FREQ=HOURLY", wantErr: true},
+		{name: "unknown part", value: "FREQ=DAILY;BYHOUR=10", wantErr: true},
+		{name: "count and until", value: "FREQ=DAILY;COUNT=3;UNTIL=20231231", wantErr: true},
+		{name: "bad weekday", value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRule)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		rule       string
+		dtstart    time.Time
+		after      time.Time
+		occurrence int
+		want       time.Time
+		wantOK     bool
+	}{
+		{
+			name:    "daily",
+			rule:    "FREQ=DAILY",
+			dtstart: at(2023, 12, 30), after: at(2023, 12, 31), occurrence: 2,
+			want: at(2024, 1, 1), wantOK: true,
+		},
+		{
+			name:    "every third day",
+			rule:    "FREQ=DAILY;INTERVAL=3",
+			dtstart: at(2023, 12, 1), after: at(2023, 12, 4), occurrence: 2,
+			want: at(2023, 12, 7), wantOK: true,
+		},
+		{
+			name:    "weekdays",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: at(2023, 12, 1), after: at(2023, 12, 1), occurrence: 1,
+			want: at(2023, 12, 4), wantOK: true,
+		},
+		{
+			name:    "every other week",
+			rule:    "FREQ=WEEKLY;INTERVAL=2",
+			dtstart: at(2023, 12, 6), after: at(2023, 12, 6), occurrence: 1,
+			want: at(2023, 12, 20), wantOK: true,
+		},
+		{
+			name:    "monthly skips short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: at(2024, 1, 31), after: at(2024, 1, 31), occurrence: 1,
+			want: at(2024, 3, 31), wantOK: true,
+		},
+		{
+			name:    "last day of month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: at(2024, 1, 31), after: at(2024, 1, 31), occurrence: 1,
+			want: at(2024, 2, 29), wantOK: true,
+		},
+		{
+			name:    "yearly leap day",
+			rule:    "FREQ=YEARLY",
+			dtstart: at(2024, 2, 29), after: at(2024, 2, 29), occurrence: 1,
+			want: at(2028, 2, 29), wantOK: true,
+		},
+		{
+			name:    "count reached",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: at(2023, 12, 1), after: at(2023, 12, 3), occurrence: 3,
+			wantOK: false,
+		},
+		{
+			name:    "until passed",
+			rule:    "FREQ=DAILY;UNTIL=20231202",
+			dtstart: at(2023, 12, 1), after: at(2023, 12, 2), occurrence: 2,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			require.NoError(t, err)
+
+			next, ok := rule.Next(tt.dtstart, tt.after, tt.occurrence)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"go-todolist-sber/pkg/logger"
+	"time"
+)
+
+// PurgeFunc удаляет записи, устаревшие к before, и возвращает их количество
+type PurgeFunc func(ctx context.Context, before time.Time) (int64, error)
+
+// Purger периодически удаляет устаревшие записи. name описывает записи в журнале, например "expired sessions"
+type Purger struct {
+	name      string
+	purge     PurgeFunc
+	log       *logger.Logger
+	interval  time.Duration
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewPurger создает очистку, которая раз в interval удаляет записи старше retention, нулевой retention
+// удаляет все записи, устаревшие к текущему моменту
+func NewPurger(name string, purge PurgeFunc, log *logger.Logger, interval, retention time.Duration) *Purger {
+	return &Purger{
+		name:      name,
+		purge:     purge,
+		log:       log,
+		interval:  interval,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Run блокируется до отмены ctx
+func (p *Purger) Run(ctx context.Context) {
+	Run(ctx, p.interval, p.Tick)
+}
+
+// Tick удаляет записи, устаревшие к now - retention. Время в базе хранится как настенное время в UTC
+func (p *Purger) Tick(ctx context.Context) {
+	now := p.now()
+	before := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.UTC).
+		Add(-p.retention)
+
+	count, err := p.purge(ctx, before)
+	if err != nil {
+		p.log.Error("purge %s: %v", p.name, err)
+		return
+	}
+
+	if count > 0 {
+		p.log.Info("purged %d %s", count, p.name)
+	}
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-todolist-sber/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestPurger_Tick(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	tests := []struct {
+		name       string
+		retention  time.Duration
+		err        error
+		wantBefore time.Time
+	}{
+		{
+			name:       "expired records are purged",
+			retention:  0,
+			wantBefore: time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "records older than retention are purged",
+			retention:  30 * 24 * time.Hour,
+			wantBefore: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "purge error",
+			retention:  0,
+			err:        errors.New("connection refused"),
+			wantBefore: time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			purge := func(ctx context.Context, before time.Time) (int64, error) {
+				calls++
+				assert.Equal(t, tt.wantBefore, before)
+				return 2, tt.err
+			}
+
+			purger := NewPurger("records", purge, logger.New(), time.Hour, tt.retention)
+			purger.now = func() time.Time { return now }
+			purger.Tick(context.Background())
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// Run вызывает fn сразу и затем каждые interval, пока не будет отменен ctx.
+// Следующий вызов не начинается, пока не завершился предыдущий
+func Run(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}